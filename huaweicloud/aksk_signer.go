@@ -0,0 +1,279 @@
+package huaweicloud
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	huaweisdk "github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/projects"
+)
+
+const (
+	akskSignAlgorithm  = "SDK-HMAC-SHA256"
+	akskDateHeader     = "X-Sdk-Date"
+	akskDateFormat     = "20060102T150405Z"
+	akskTokenHeader    = "X-Security-Token"
+	akskProjectHeader  = "X-Project-Id"
+	akskHeaderSignedBy = "host"
+)
+
+// akskRoundTripper signs every request with the AK/SK returned by a
+// credentialChain, which lets the service clients work without a token.
+type akskRoundTripper struct {
+	Rt          http.RoundTripper
+	Credentials *credentialChain
+
+	// ProjectID is sent with every request once it is known.
+	ProjectID string
+}
+
+// RoundTrip signs the request and passes it on.
+func (rt *akskRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	v, err := rt.Credentials.Get()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting credentials to sign request: %s", err)
+	}
+
+	var body []byte
+	if request.Body != nil {
+		body, err = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if rt.ProjectID != "" && request.Header.Get(akskProjectHeader) == "" {
+		request.Header.Set(akskProjectHeader, rt.ProjectID)
+	}
+
+	signAKSKRequest(request, body, v, time.Now())
+
+	return rt.Rt.RoundTrip(request)
+}
+
+// signAKSKRequest adds the X-Sdk-Date, X-Security-Token and Authorization
+// headers of the SDK-HMAC-SHA256 signature to request.
+func signAKSKRequest(request *http.Request, body []byte, v credentialValue, now time.Time) {
+	request.Header.Set(akskDateHeader, now.UTC().Format(akskDateFormat))
+	if v.SecurityToken != "" {
+		request.Header.Set(akskTokenHeader, v.SecurityToken)
+	}
+
+	signedHeaders := akskSignedHeaders(request)
+	canonical := strings.Join([]string{
+		request.Method,
+		akskCanonicalURI(request.URL),
+		akskCanonicalQueryString(request.URL),
+		akskCanonicalHeaders(request, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		hexSHA256(body),
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		akskSignAlgorithm,
+		request.Header.Get(akskDateHeader),
+		hexSHA256([]byte(canonical)),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(v.SecretKey))
+	mac.Write([]byte(stringToSign))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Access=%s, SignedHeaders=%s, Signature=%s",
+		akskSignAlgorithm, v.AccessKey, strings.Join(signedHeaders, ";"), signature))
+}
+
+// akskSignedHeaders returns the sorted, lower-cased names of the headers
+// covered by the signature: the host and the headers set by the signer.
+func akskSignedHeaders(request *http.Request) []string {
+	headers := []string{akskHeaderSignedBy}
+	for name := range request.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || strings.HasPrefix(name, "x-") {
+			headers = append(headers, name)
+		}
+	}
+	sort.Strings(headers)
+	return headers
+}
+
+func akskCanonicalHeaders(request *http.Request, signedHeaders []string) string {
+	var b bytes.Buffer
+	for _, name := range signedHeaders {
+		value := request.Header.Get(name)
+		if name == akskHeaderSignedBy {
+			value = request.Host
+			if value == "" {
+				value = request.URL.Host
+			}
+		}
+		b.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	return b.String()
+}
+
+// akskCanonicalURI escapes every path segment and always ends with a slash.
+func akskCanonicalURI(u *url.URL) string {
+	segments := strings.Split(u.Path, "/")
+	for i, s := range segments {
+		segments[i] = akskEscape(s)
+	}
+
+	uri := strings.Join(segments, "/")
+	if !strings.HasSuffix(uri, "/") {
+		uri += "/"
+	}
+	return uri
+}
+
+func akskCanonicalQueryString(u *url.URL) string {
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, akskEscape(k)+"="+akskEscape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// akskEscape percent-encodes s as described by RFC 3986.
+func akskEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// akskEndpointTemplates maps the service types requested by the clients to
+// their public endpoints. With AK/SK authentication there is no token and
+// so no service catalog to look them up in.
+var akskEndpointTemplates = map[string]string{
+	"compute":       "https://ecs.{region}.{domain}/v2/{project_id}/",
+	"network":       "https://vpc.{region}.{domain}/",
+	"load-balancer": "https://vpc.{region}.{domain}/",
+	"volume":        "https://evs.{region}.{domain}/v1/{project_id}/",
+	"volumev2":      "https://evs.{region}.{domain}/v2/{project_id}/",
+	"volumev3":      "https://evs.{region}.{domain}/v3/{project_id}/",
+	"evs":           "https://evs.{region}.{domain}/v2/{project_id}/",
+	"sharev2":       "https://sfs.{region}.{domain}/v2/{project_id}/",
+	"image":         "https://ims.{region}.{domain}/",
+	"orchestration": "https://rts.{region}.{domain}/v1/{project_id}/",
+	"database":      "https://rds.{region}.{domain}/v1.0/{project_id}/",
+	"ces":           "https://ces.{region}.{domain}/V1.0/{project_id}/",
+	"as":            "https://as.{region}.{domain}/autoscaling-api/v1/{project_id}/",
+	"elb":           "https://elb.{region}.{domain}/v1.0/{project_id}/",
+	"antiddos":      "https://antiddos.{region}.{domain}/",
+	"mrs":           "https://mrs.{region}.{domain}/v1.1/",
+	"deh":           "https://deh.{region}.{domain}/v1.0/{project_id}/",
+	"cdn":           "https://cdn.{domain}/v1.0/",
+	"object":        "https://obs.{region}.{domain}/",
+	"object-store":  "https://obs.{region}.{domain}/v1/AUTH_{project_id}/",
+	"dns":           "https://dns.{domain}/",
+	"identity":      "https://iam.{domain}/v3/",
+}
+
+// akskEndpointURL builds the endpoint of a service from akskEndpointTemplates.
+func akskEndpointURL(opts golangsdk.EndpointOpts, domain, projectID string) (string, error) {
+	template, ok := akskEndpointTemplates[opts.Type]
+	if !ok {
+		return "", &golangsdk.ErrEndpointNotFound{}
+	}
+
+	if opts.Region == "" && strings.Contains(template, "{region}") {
+		return "", fmt.Errorf("A region is required to find the %s endpoint", opts.Type)
+	}
+
+	r := strings.NewReplacer("{region}", opts.Region, "{domain}", domain, "{project_id}", projectID)
+	return r.Replace(template), nil
+}
+
+// akskCloudDomain returns the domain the endpoints of a cloud live under,
+// taken from the host of its identity endpoint (https://iam.{domain}/v3).
+func akskCloudDomain(identityEndpoint, region string) (string, error) {
+	u, err := url.Parse(identityEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	host := u.Hostname()
+	if !strings.HasPrefix(host, "iam.") {
+		return "", fmt.Errorf("Unable to derive the cloud domain from auth_url %q, "+
+			"it should look like https://iam.myhuaweicloud.com/v3", identityEndpoint)
+	}
+	host = strings.TrimPrefix(host, "iam.")
+	if region != "" {
+		host = strings.TrimPrefix(host, region+".")
+	}
+	return host, nil
+}
+
+// akskAuthenticate prepares client for AK/SK authentication: it resolves the
+// project to scope requests to and installs the endpoint locator.
+func akskAuthenticate(client *golangsdk.ProviderClient, signer *akskRoundTripper, c *Config) error {
+	domain, err := akskCloudDomain(client.IdentityEndpoint, c.Region)
+	if err != nil {
+		return err
+	}
+
+	projectID := c.TenantID
+	if projectID == "" {
+		// Huawei Cloud names the default project of a region after the region.
+		name := c.TenantName
+		if name == "" {
+			name = c.Region
+		}
+		if name == "" {
+			return fmt.Errorf("One of tenant_id, tenant_name or region must be set to use AK/SK authentication")
+		}
+
+		identity, err := huaweisdk.NewIdentityV3(client, golangsdk.EndpointOpts{})
+		if err != nil {
+			return err
+		}
+		allPages, err := projects.List(identity, projects.ListOpts{Name: name}).AllPages()
+		if err != nil {
+			return fmt.Errorf("Error looking up project %s: %s", name, err)
+		}
+		allProjects, err := projects.ExtractProjects(allPages)
+		if err != nil {
+			return fmt.Errorf("Error extracting projects: %s", err)
+		}
+		if len(allProjects) != 1 {
+			return fmt.Errorf("Expected exactly one project named %s, found %d", name, len(allProjects))
+		}
+		projectID = allProjects[0].ID
+	}
+
+	log.Printf("[DEBUG] Using AK/SK authentication with project %s", projectID)
+	client.ProjectID = projectID
+	signer.ProjectID = projectID
+	client.EndpointLocator = func(opts golangsdk.EndpointOpts) (string, error) {
+		return akskEndpointURL(opts, domain, projectID)
+	}
+
+	return nil
+}
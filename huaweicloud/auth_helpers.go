@@ -1,41 +1,267 @@
 package huaweicloud
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
 	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
-	"github.com/aws/aws-sdk-go/aws/defaults"
-	"github.com/aws/aws-sdk-go/aws/ec2metadata"
-	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-ini/ini"
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	homedir "github.com/mitchellh/go-homedir"
 )
 
-func GetCredentials(c *Config) (*awsCredentials.Credentials, error) {
-	// build a chain provider, lazy-evaluated by aws-sdk
-	providers := []awsCredentials.Provider{
-		&awsCredentials.StaticProvider{Value: awsCredentials.Value{
-			AccessKeyID:     c.AccessKey,
-			SecretAccessKey: c.SecretKey,
-			SessionToken:    c.Token,
-		}},
-		&awsCredentials.EnvProvider{},
-		&awsCredentials.SharedCredentialsProvider{
-			Filename: "",
-			Profile:  "",
-		},
+const (
+	// defaultMetadataURL is the ECS metadata service. It hands out the
+	// temporary AK/SK of the agency attached to the instance.
+	defaultMetadataURL = "http://169.254.169.254"
+
+	// metadataSecurityKeyPath is the metadata path of the temporary AK/SK.
+	metadataSecurityKeyPath = "/openstack/latest/securitykey"
+
+	// credentialsRefreshWindow is how long before their expiry temporary
+	// credentials are refreshed.
+	credentialsRefreshWindow = 5 * time.Minute
+)
+
+// errNoCredentials is returned by a credentialProvider which has nothing to
+// offer, so that the chain moves on to the next provider.
+var errNoCredentials = errors.New("no credentials found")
+
+// credentialValue is an AK/SK pair with its optional security token.
+// Expires is zero for permanent credentials.
+type credentialValue struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+	Expires       time.Time
+	ProviderName  string
+}
+
+func (v credentialValue) needsRefresh(now time.Time) bool {
+	return !v.Expires.IsZero() && now.Add(credentialsRefreshWindow).After(v.Expires)
+}
+
+// credentialProvider is a single source of credentials in a credentialChain.
+type credentialProvider interface {
+	Retrieve() (credentialValue, error)
+}
+
+// staticCredentialProvider returns the credentials given to the provider,
+// either as arguments or through their environment variables.
+type staticCredentialProvider struct {
+	Value credentialValue
+}
+
+func (p *staticCredentialProvider) Retrieve() (credentialValue, error) {
+	if p.Value.AccessKey == "" || p.Value.SecretKey == "" {
+		return credentialValue{}, errNoCredentials
+	}
+
+	v := p.Value
+	v.ProviderName = "StaticProvider"
+	return v, nil
+}
+
+// profileCredentialProvider reads credentials from a profile of an INI file,
+// ~/.huaweicloud/credentials by default:
+//
+//	[default]
+//	access_key     = ...
+//	secret_key     = ...
+//	security_token = ...
+type profileCredentialProvider struct {
+	Filename string
+	Profile  string
+}
+
+func (p *profileCredentialProvider) Retrieve() (credentialValue, error) {
+	filename, err := p.filename()
+	if err != nil {
+		return credentialValue{}, err
+	}
+
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		log.Printf("[DEBUG] Shared credentials file %s does not exist", filename)
+		return credentialValue{}, errNoCredentials
+	}
+
+	f, err := ini.Load(filename)
+	if err != nil {
+		return credentialValue{}, fmt.Errorf("Error loading shared credentials file %s: %s", filename, err)
+	}
+
+	profile := p.profile()
+	section, err := f.GetSection(profile)
+	if err != nil {
+		return credentialValue{}, fmt.Errorf("Profile %q not found in shared credentials file %s", profile, filename)
+	}
+
+	v := credentialValue{
+		AccessKey:     section.Key("access_key").String(),
+		SecretKey:     section.Key("secret_key").String(),
+		SecurityToken: section.Key("security_token").String(),
+		ProviderName:  "SharedCredentialsProvider",
+	}
+	if v.AccessKey == "" || v.SecretKey == "" {
+		return credentialValue{}, fmt.Errorf("Profile %q in shared credentials file %s must set access_key and secret_key", profile, filename)
+	}
+
+	return v, nil
+}
+
+func (p *profileCredentialProvider) filename() (string, error) {
+	if p.Filename != "" {
+		return homedir.Expand(p.Filename)
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", fmt.Errorf("Error finding home directory: %s", err)
+	}
+	return filepath.Join(home, ".huaweicloud", "credentials"), nil
+}
+
+func (p *profileCredentialProvider) profile() string {
+	if p.Profile != "" {
+		return p.Profile
 	}
+	return "default"
+}
+
+// metadataCredentialProvider fetches the temporary credentials of the agency
+// attached to the ECS instance Terraform runs on.
+type metadataCredentialProvider struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+type metadataSecurityKey struct {
+	Credential struct {
+		Access        string `json:"access"`
+		Secret        string `json:"secret"`
+		SecurityToken string `json:"securitytoken"`
+		ExpiresAt     string `json:"expires_at"`
+	} `json:"credential"`
+}
 
+func (p *metadataCredentialProvider) Retrieve() (credentialValue, error) {
+	url := p.Endpoint + metadataSecurityKeyPath
+	resp, err := p.Client.Get(url)
+	if err != nil {
+		log.Printf("[DEBUG] Ignoring ECS metadata API endpoint at %s: %s", p.Endpoint, err)
+		return credentialValue{}, errNoCredentials
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		log.Printf("[DEBUG] No agency attached to the ECS instance, ignoring ECS metadata API endpoint at %s", p.Endpoint)
+		return credentialValue{}, errNoCredentials
+	}
+	if resp.StatusCode != http.StatusOK {
+		return credentialValue{}, fmt.Errorf("Error fetching credentials from %s: unexpected status %d", url, resp.StatusCode)
+	}
+
+	var key metadataSecurityKey
+	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
+		return credentialValue{}, fmt.Errorf("Error decoding credentials from %s: %s", url, err)
+	}
+
+	v := credentialValue{
+		AccessKey:     key.Credential.Access,
+		SecretKey:     key.Credential.Secret,
+		SecurityToken: key.Credential.SecurityToken,
+		ProviderName:  "ECSMetadataProvider",
+	}
+	if v.AccessKey == "" || v.SecretKey == "" {
+		return credentialValue{}, fmt.Errorf("Error fetching credentials from %s: response has no access or secret key", url)
+	}
+
+	if key.Credential.ExpiresAt != "" {
+		v.Expires, err = time.Parse(time.RFC3339, key.Credential.ExpiresAt)
+		if err != nil {
+			return credentialValue{}, fmt.Errorf("Error parsing credentials expiry %q: %s", key.Credential.ExpiresAt, err)
+		}
+	}
+
+	return v, nil
+}
+
+// credentialChain returns the credentials of the first provider which has
+// any, and keeps using that provider to refresh them before they expire.
+type credentialChain struct {
+	Providers []credentialProvider
+
+	mu       sync.Mutex
+	current  credentialValue
+	provider credentialProvider
+	now      func() time.Time
+}
+
+// Get returns valid credentials, refreshing them if they are about to expire.
+func (c *credentialChain) Get() (credentialValue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+
+	if c.provider != nil {
+		if !c.current.needsRefresh(now()) {
+			return c.current, nil
+		}
+
+		log.Printf("[DEBUG] Refreshing credentials from %s, they expire at %s",
+			c.current.ProviderName, c.current.Expires.Format(time.RFC3339))
+		v, err := c.provider.Retrieve()
+		if err != nil {
+			if now().Before(c.current.Expires) {
+				log.Printf("[WARN] Error refreshing credentials, using the current ones: %s", err)
+				return c.current, nil
+			}
+			return credentialValue{}, fmt.Errorf("Error refreshing expired credentials: %s", err)
+		}
+		c.current = v
+		return v, nil
+	}
+
+	for _, p := range c.Providers {
+		v, err := p.Retrieve()
+		if err == errNoCredentials {
+			continue
+		}
+		if err != nil {
+			return credentialValue{}, err
+		}
+
+		log.Printf("[INFO] Credentials provider used: %s", v.ProviderName)
+		c.current = v
+		c.provider = p
+		return v, nil
+	}
+
+	return credentialValue{}, errNoCredentials
+}
+
+// newCredentialChain builds the chain of credential sources: the provider
+// arguments, then the shared credentials file and lastly the ECS metadata
+// service.
+func newCredentialChain(c *Config) *credentialChain {
 	// Build isolated HTTP client to avoid issues with globally-shared settings
 	client := cleanhttp.DefaultClient()
 
-	// Keep the default timeout (100ms) low as we don't want to wait in non-EC2 environments
+	// Keep the default timeout (100ms) low as we don't want to wait in non-ECS environments
 	client.Timeout = 100 * time.Millisecond
 
-	const userTimeoutEnvVar = "AWS_METADATA_TIMEOUT"
+	const userTimeoutEnvVar = "HW_METADATA_TIMEOUT"
 	userTimeout := os.Getenv(userTimeoutEnvVar)
 	if userTimeout != "" {
 		newTimeout, err := time.ParseDuration(userTimeout)
@@ -50,45 +276,63 @@ func GetCredentials(c *Config) (*awsCredentials.Credentials, error) {
 		}
 	}
 
-	log.Printf("[INFO] Setting AWS metadata API timeout to %s", client.Timeout.String())
-	cfg := &aws.Config{
-		HTTPClient: client,
+	endpoint := defaultMetadataURL
+	if v := os.Getenv("HW_METADATA_URL"); v != "" {
+		log.Printf("[INFO] Setting custom metadata endpoint: %q", v)
+		endpoint = v
 	}
-	usedEndpoint := setOptionalEndpoint(cfg)
 
-	// Add the default AWS provider for ECS Task Roles if the relevant env variable is set
-	if uri := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); len(uri) > 0 {
-		providers = append(providers, defaults.RemoteCredProvider(*cfg, defaults.Handlers()))
-		log.Print("[INFO] ECS container credentials detected, RemoteCredProvider added to auth chain")
+	return &credentialChain{
+		Providers: []credentialProvider{
+			&staticCredentialProvider{Value: credentialValue{
+				AccessKey:     c.AccessKey,
+				SecretKey:     c.SecretKey,
+				SecurityToken: c.SecurityToken,
+			}},
+			&profileCredentialProvider{
+				Filename: c.SharedCredentialsFile,
+				Profile:  c.Profile,
+			},
+			&metadataCredentialProvider{
+				Endpoint: endpoint,
+				Client:   client,
+			},
+		},
 	}
+}
 
-	// Real AWS should reply to a simple metadata request.
-	// We check it actually does to ensure something else didn't just
-	// happen to be listening on the same IP:Port
-	metadataClient := ec2metadata.New(session.New(cfg))
-	if metadataClient.Available() {
-		providers = append(providers, &ec2rolecreds.EC2RoleProvider{
-			Client: metadataClient,
-		})
-		log.Print("[INFO] AWS EC2 instance detected via default metadata" +
-			" API endpoint, EC2RoleProvider added to the auth chain")
-	} else {
-		if usedEndpoint == "" {
-			usedEndpoint = "default location"
-		}
-		log.Printf("[INFO] Ignoring AWS metadata API endpoint at %s "+
-			"as it doesn't return any instance-id", usedEndpoint)
+// s3CredentialProvider adapts a credentialChain to the aws-sdk-go
+// credentials.Provider interface used by the S3 client.
+type s3CredentialProvider struct {
+	chain   *credentialChain
+	expires time.Time
+}
+
+func (p *s3CredentialProvider) Retrieve() (awsCredentials.Value, error) {
+	v, err := p.chain.Get()
+	if err != nil {
+		return awsCredentials.Value{}, err
 	}
 
-	return awsCredentials.NewChainCredentials(providers), nil
+	p.expires = v.Expires
+	return awsCredentials.Value{
+		AccessKeyID:     v.AccessKey,
+		SecretAccessKey: v.SecretKey,
+		SessionToken:    v.SecurityToken,
+		ProviderName:    v.ProviderName,
+	}, nil
+}
+
+func (p *s3CredentialProvider) IsExpired() bool {
+	return !p.expires.IsZero() && time.Now().Add(credentialsRefreshWindow).After(p.expires)
 }
 
-func setOptionalEndpoint(cfg *aws.Config) string {
-	endpoint := os.Getenv("AWS_METADATA_URL")
-	if endpoint != "" {
-		log.Printf("[INFO] Setting custom metadata endpoint: %q", endpoint)
-		cfg.Endpoint = aws.String(endpoint)
-		return endpoint
+// GetCredentials returns the credentials of the S3 client, backed by the
+// provider's credential chain.
+func GetCredentials(c *Config) (*awsCredentials.Credentials, error) {
+	if c.credentials == nil {
+		return nil, fmt.Errorf("No valid credential sources found for S3 Provider.")
 	}
-	return ""
+
+	return awsCredentials.NewCredentials(&s3CredentialProvider{chain: c.credentials}), nil
 }
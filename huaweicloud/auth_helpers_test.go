@@ -0,0 +1,265 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/huaweicloud/golangsdk"
+	huaweisdk "github.com/huaweicloud/golangsdk/openstack"
+)
+
+func TestCredentialChain_static(t *testing.T) {
+	chain := newCredentialChain(&Config{
+		AccessKey:             "static-ak",
+		SecretKey:             "static-sk",
+		SharedCredentialsFile: "/nonexistent/credentials",
+	})
+
+	v, err := chain.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKey != "static-ak" || v.ProviderName != "StaticProvider" {
+		t.Fatalf("unexpected credentials: %#v", v)
+	}
+}
+
+func TestCredentialChain_profile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "credentials")
+	contents := `
+[default]
+access_key = default-ak
+secret_key = default-sk
+
+[build]
+access_key     = build-ak
+secret_key     = build-sk
+security_token = build-token
+`
+	if err := ioutil.WriteFile(filename, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := newCredentialChain(&Config{SharedCredentialsFile: filename, Profile: "build"}).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKey != "build-ak" || v.SecurityToken != "build-token" {
+		t.Fatalf("unexpected credentials: %#v", v)
+	}
+
+	_, err = newCredentialChain(&Config{SharedCredentialsFile: filename, Profile: "missing"}).Get()
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected an error for the missing profile, got %v", err)
+	}
+}
+
+func TestCredentialChain_metadata(t *testing.T) {
+	var calls int
+	expires := time.Now().Add(time.Hour).UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != metadataSecurityKeyPath {
+			http.NotFound(w, r)
+			return
+		}
+		calls++
+		fmt.Fprintf(w, `{"credential": {"access": "temp-ak-%d", "secret": "temp-sk", "securitytoken": "temp-token", "expires_at": %q}}`,
+			calls, expires.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	os.Setenv("HW_METADATA_URL", server.URL)
+	os.Setenv("HW_METADATA_TIMEOUT", "5s")
+	defer os.Unsetenv("HW_METADATA_URL")
+	defer os.Unsetenv("HW_METADATA_TIMEOUT")
+
+	chain := newCredentialChain(&Config{SharedCredentialsFile: "/nonexistent/credentials"})
+	now := time.Now()
+	chain.now = func() time.Time { return now }
+
+	v, err := chain.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKey != "temp-ak-1" || v.SecurityToken != "temp-token" || v.ProviderName != "ECSMetadataProvider" {
+		t.Fatalf("unexpected credentials: %#v", v)
+	}
+
+	// The credentials are cached while they are valid.
+	if v, _ = chain.Get(); v.AccessKey != "temp-ak-1" {
+		t.Fatalf("expected cached credentials, got %#v", v)
+	}
+
+	// And refreshed shortly before they expire.
+	now = expires.Add(-time.Minute)
+	if v, _ = chain.Get(); v.AccessKey != "temp-ak-2" {
+		t.Fatalf("expected refreshed credentials, got %#v", v)
+	}
+}
+
+func TestAKSKRoundTripper(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+	}))
+	defer server.Close()
+
+	chain := &credentialChain{Providers: []credentialProvider{
+		&staticCredentialProvider{Value: credentialValue{
+			AccessKey:     "ak",
+			SecretKey:     "sk",
+			SecurityToken: "token",
+		}},
+	}}
+	client := http.Client{Transport: &akskRoundTripper{
+		Rt:          http.DefaultTransport,
+		Credentials: chain,
+		ProjectID:   "project",
+	}}
+
+	resp, err := client.Post(server.URL+"/v1/resources?b=2&a=1", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	auth := headers.Get("Authorization")
+	if !strings.HasPrefix(auth, "SDK-HMAC-SHA256 Access=ak, SignedHeaders=content-type;host;x-project-id;x-sdk-date;x-security-token, Signature=") {
+		t.Fatalf("unexpected Authorization header: %s", auth)
+	}
+	if headers.Get("X-Security-Token") != "token" || headers.Get("X-Project-Id") != "project" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+}
+
+func TestAKSKEndpointURL(t *testing.T) {
+	domain, err := akskCloudDomain("https://iam.cn-north-1.myhuaweicloud.com/v3", "cn-north-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domain != "myhuaweicloud.com" {
+		t.Fatalf("unexpected domain: %s", domain)
+	}
+
+	url, err := akskEndpointURL(golangsdk.EndpointOpts{Type: "compute", Region: "cn-north-1"}, domain, "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "https://ecs.cn-north-1.myhuaweicloud.com/v2/project/" {
+		t.Fatalf("unexpected endpoint: %s", url)
+	}
+}
+
+// TestAKSKEndpointURL_allClients checks that every client can find its
+// endpoint with AK/SK authentication, where there is no service catalog.
+func TestAKSKEndpointURL_allClients(t *testing.T) {
+	c := &Config{Region: "cn-north-1"}
+	c.HwClient = &golangsdk.ProviderClient{
+		IdentityBase: "https://iam.myhuaweicloud.com/",
+		ProjectID:    "project",
+		EndpointLocator: func(opts golangsdk.EndpointOpts) (string, error) {
+			return akskEndpointURL(opts, "myhuaweicloud.com", "project")
+		},
+	}
+	c.OsClient = &gophercloud.ProviderClient{
+		IdentityBase:    "https://iam.myhuaweicloud.com/",
+		EndpointLocator: c.osEndpointLocator,
+	}
+
+	gopherClient := func(f func(string) (*gophercloud.ServiceClient, error)) func() (string, error) {
+		return func() (string, error) {
+			sc, err := f(c.Region)
+			if err != nil {
+				return "", err
+			}
+			return sc.Endpoint, nil
+		}
+	}
+	hwClient := func(f func(string) (*golangsdk.ServiceClient, error)) func() (string, error) {
+		return func() (string, error) {
+			sc, err := f(c.Region)
+			if err != nil {
+				return "", err
+			}
+			return sc.Endpoint, nil
+		}
+	}
+	sdkClient := func(f func(*golangsdk.ProviderClient, golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error)) func() (string, error) {
+		return hwClient(func(region string) (*golangsdk.ServiceClient, error) {
+			return f(c.HwClient, golangsdk.EndpointOpts{Region: region})
+		})
+	}
+
+	clients := map[string]func() (string, error){
+		"blockStorageV1Client":          gopherClient(c.blockStorageV1Client),
+		"blockStorageV2Client":          gopherClient(c.blockStorageV2Client),
+		"computeV2Client":               gopherClient(c.computeV2Client),
+		"dnsV2Client":                   hwClient(c.dnsV2Client),
+		"identityV3Client":              gopherClient(c.identityV3Client),
+		"imageV2Client":                 gopherClient(c.imageV2Client),
+		"networkingV1Client":            hwClient(c.networkingV1Client),
+		"networkingV2Client":            gopherClient(c.networkingV2Client),
+		"objectStorageV1Client":         gopherClient(c.objectStorageV1Client),
+		"loadBalancerV2Client":          gopherClient(c.loadBalancerV2Client),
+		"databaseV1Client":              gopherClient(c.databaseV1Client),
+		"fwV2Client":                    hwClient(c.fwV2Client),
+		"vpnV2Client":                   hwClient(c.vpnV2Client),
+		"loadElasticLoadBalancerClient": hwClient(c.loadElasticLoadBalancerClient),
+		"kmsKeyV1Client":                hwClient(c.kmsKeyV1Client),
+		"kmsKeyPairV3Client":            hwClient(c.kmsKeyPairV3Client),
+		"natV2Client":                   hwClient(c.natV2Client),
+		"SmnV2Client":                   hwClient(c.SmnV2Client),
+		"RdsV1Client":                   hwClient(c.RdsV1Client),
+		"loadCESClient":                 hwClient(c.loadCESClient),
+		"loadIAMV3Client":               hwClient(c.loadIAMV3Client),
+		"sfsV2Client":                   hwClient(c.sfsV2Client),
+		"orchestrationV1Client":         hwClient(c.orchestrationV1Client),
+		"networkingHwV2Client":          hwClient(c.networkingHwV2Client),
+
+		// The service clients of golangsdk the provider doesn't wrap yet.
+		"NewBlockStorageV3":      sdkClient(huaweisdk.NewBlockStorageV3),
+		"NewSharedFileSystemV2":  sdkClient(huaweisdk.NewSharedFileSystemV2),
+		"NewCDNV1":               sdkClient(huaweisdk.NewCDNV1),
+		"NewDBV1":                sdkClient(huaweisdk.NewDBV1),
+		"NewObjectStorageV1":     sdkClient(huaweisdk.NewObjectStorageV1),
+		"NewDRSServiceV2":        sdkClient(huaweisdk.NewDRSServiceV2),
+		"NewComputeV1":           sdkClient(huaweisdk.NewComputeV1),
+		"NewAutoScalingService":  sdkClient(huaweisdk.NewAutoScalingService),
+		"NewMapReduceV1":         sdkClient(huaweisdk.NewMapReduceV1),
+		"NewAntiDDoSV1":          sdkClient(huaweisdk.NewAntiDDoSV1),
+		"NewAntiDDoSV2":          sdkClient(huaweisdk.NewAntiDDoSV2),
+		"NewDMSServiceV1":        sdkClient(huaweisdk.NewDMSServiceV1),
+		"NewDCSServiceV1":        sdkClient(huaweisdk.NewDCSServiceV1),
+		"NewOBSService":          sdkClient(huaweisdk.NewOBSService),
+		"NewDeHServiceV1":        sdkClient(huaweisdk.NewDeHServiceV1),
+		"NewElasticLoadBalancer": sdkClient(huaweisdk.NewElasticLoadBalancer),
+		"NewElbV1": sdkClient(func(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
+			return huaweisdk.NewElbV1(client, eo, "elb")
+		}),
+	}
+
+	for name, endpoint := range clients {
+		url, err := endpoint()
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if !strings.HasPrefix(url, "https://") || !strings.Contains(url, "myhuaweicloud.com/") {
+			t.Errorf("%s: unexpected endpoint %s", name, url)
+		}
+	}
+}
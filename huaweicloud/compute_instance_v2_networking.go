@@ -15,6 +15,7 @@ import (
 	"os"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/tenantnetworks"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
@@ -423,21 +424,43 @@ func flattenInstanceNetworks(
 		return networks, nil
 	}
 
+	// Interfaces hot-plugged into the instance, for example by a
+	// huaweicloud_compute_interface_attach_v2 resource, show up in the
+	// addresses as well. Ports are resolved to their MAC addresses so that
+	// every network block is matched with its own NIC and the attached
+	// interfaces are left out.
+	portMACs := getInstancePortMACs(computeClient, d.Id())
+
+	reserved := reservedInstanceNICs(allInstanceNetworks, portMACs)
+	macPorts := make(map[string]string)
+	for port, mac := range portMACs {
+		macPorts[mac] = port
+	}
+
 	// Loop through all networks and addresses, merge relevant address details.
+	claimed := make(map[string]bool)
 	for _, instanceNetwork := range allInstanceNetworks {
 		for _, instanceAddresses := range allInstanceAddresses {
 			if instanceNetwork.Name == instanceAddresses.NetworkName {
 				// Only use one NIC since it's possible the user defined another NIC
 				// on this same network in another Terraform network block.
-				instanceNIC := instanceAddresses.InstanceNICs[0]
-				copy(instanceAddresses.InstanceNICs, instanceAddresses.InstanceNICs[1:])
+				instanceNIC, ok := claimInstanceNIC(instanceNetwork, instanceAddresses, portMACs, reserved, claimed)
+				if !ok {
+					continue
+				}
+				// Record the port of the NIC, so that the ports of the
+				// instance are known by the next refresh.
+				port := instanceNetwork.Port
+				if port == "" {
+					port = macPorts[instanceNIC.MAC]
+				}
 				v := map[string]interface{}{
 					"name":           instanceAddresses.NetworkName,
 					"fixed_ip_v4":    instanceNIC.FixedIPv4,
 					"fixed_ip_v6":    instanceNIC.FixedIPv6,
 					"mac":            instanceNIC.MAC,
 					"uuid":           instanceNetwork.UUID,
-					"port":           instanceNetwork.Port,
+					"port":           port,
 					"access_network": instanceNetwork.AccessNetwork,
 				}
 				networks = append(networks, v)
@@ -449,6 +472,94 @@ func flattenInstanceNetworks(
 	return networks, nil
 }
 
+// getInstancePortMACs maps the ports of the instance to their MAC addresses.
+// The lookup is best effort, an empty map is returned if the interfaces
+// can't be listed.
+func getInstancePortMACs(computeClient *gophercloud.ServiceClient, instanceId string) map[string]string {
+	portMACs := make(map[string]string)
+
+	allPages, err := attachinterfaces.List(computeClient, instanceId).AllPages()
+	if err != nil {
+		log.Printf("[DEBUG] Unable to list interfaces of instance %s: %s", instanceId, err)
+		return portMACs
+	}
+
+	allInterfaces, err := attachinterfaces.ExtractInterfaces(allPages)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to extract interfaces of instance %s: %s", instanceId, err)
+		return portMACs
+	}
+
+	for _, v := range allInterfaces {
+		portMACs[v.PortID] = v.MACAddr
+	}
+
+	return portMACs
+}
+
+// reservedInstanceNICs returns the MACs of the NICs which may only be claimed
+// by the network block of their port. Once every network block has recorded
+// its port, the NICs of all other ports are reserved as well, so that
+// hot-plugged interfaces are never claimed. Until then, only the NICs of the
+// known ports are.
+func reservedInstanceNICs(instanceNetworks []InstanceNetwork, portMACs map[string]string) map[string]bool {
+	ownPorts := make(map[string]bool)
+	allKnown := true
+	for _, instanceNetwork := range instanceNetworks {
+		if instanceNetwork.Port == "" {
+			allKnown = false
+		}
+		ownPorts[instanceNetwork.Port] = true
+	}
+
+	reserved := make(map[string]bool)
+	for port, mac := range portMACs {
+		if allKnown || ownPorts[port] {
+			reserved[mac] = true
+		}
+	}
+	return reserved
+}
+
+// claimInstanceNIC picks the NIC of instanceAddresses that belongs to
+// instanceNetwork and marks it as claimed. A NIC is matched by the MAC of
+// its port or by the configured fixed IP, otherwise the first unclaimed NIC
+// that isn't reserved is used.
+func claimInstanceNIC(instanceNetwork InstanceNetwork, instanceAddresses InstanceAddresses,
+	portMACs map[string]string, reserved, claimed map[string]bool) (InstanceNIC, bool) {
+
+	var mac string
+	if instanceNetwork.Port != "" {
+		mac = portMACs[instanceNetwork.Port]
+	}
+
+	for _, instanceNIC := range instanceAddresses.InstanceNICs {
+		if claimed[instanceNIC.MAC] {
+			continue
+		}
+
+		switch {
+		case mac != "":
+			if instanceNIC.MAC != mac {
+				continue
+			}
+		case instanceNetwork.FixedIP != "":
+			if instanceNIC.FixedIPv4 != instanceNetwork.FixedIP {
+				continue
+			}
+		default:
+			if reserved[instanceNIC.MAC] {
+				continue
+			}
+		}
+
+		claimed[instanceNIC.MAC] = true
+		return instanceNIC, true
+	}
+
+	return InstanceNIC{}, false
+}
+
 // getInstanceAccessAddresses determines the best IP address to communicate
 // with the instance. It does this by looping through all networks and looking
 // for a valid IP address. Priority is given to a network that was flagged as
@@ -0,0 +1,37 @@
+package huaweicloud
+
+import (
+	"testing"
+)
+
+func TestClaimInstanceNIC_attachedInterface(t *testing.T) {
+	instanceAddresses := InstanceAddresses{
+		NetworkName: "net",
+		InstanceNICs: []InstanceNIC{
+			{FixedIPv4: "192.168.0.10", MAC: "fa:16:3e:00:00:01"},
+			{FixedIPv4: "192.168.0.11", MAC: "fa:16:3e:00:00:02"},
+		},
+	}
+	portMACs := map[string]string{
+		"port-attached": "fa:16:3e:00:00:01",
+		"port-own":      "fa:16:3e:00:00:02",
+	}
+
+	// Once the port of the network block is recorded, the NIC of the
+	// attached port is never claimed.
+	instanceNetwork := InstanceNetwork{Name: "net", Port: "port-own"}
+	reserved := reservedInstanceNICs([]InstanceNetwork{instanceNetwork}, portMACs)
+	nic, ok := claimInstanceNIC(instanceNetwork, instanceAddresses, portMACs, reserved, make(map[string]bool))
+	if !ok || nic.MAC != "fa:16:3e:00:00:02" {
+		t.Fatalf("expected the NIC of port-own, got %#v", nic)
+	}
+
+	// Without a port, a network block claims the first NIC that isn't
+	// reserved by another network block.
+	instanceNetworks := []InstanceNetwork{{Name: "net"}, {Name: "net", Port: "port-attached"}}
+	reserved = reservedInstanceNICs(instanceNetworks, portMACs)
+	nic, ok = claimInstanceNIC(instanceNetworks[0], instanceAddresses, portMACs, reserved, make(map[string]bool))
+	if !ok || nic.MAC != "fa:16:3e:00:00:02" {
+		t.Fatalf("expected the NIC of port-own, got %#v", nic)
+	}
+}
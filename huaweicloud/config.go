@@ -7,6 +7,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -22,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/huaweicloud/golangsdk"
 	huaweisdk "github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
 )
 
 type Config struct {
@@ -49,9 +52,20 @@ type Config struct {
 	AgencyDomainName string
 	DelegatedProject string
 
+	SecurityToken         string
+	SharedCredentialsFile string
+	Profile               string
+
 	OsClient *gophercloud.ProviderClient
 	HwClient *golangsdk.ProviderClient
 	s3sess   *session.Session
+
+	credentials *credentialChain
+	akskSigner  *akskRoundTripper
+
+	// transport is the HTTP transport of the clients, before logging and
+	// signing, for the services which sign requests their own way.
+	transport *http.Transport
 }
 
 func (c *Config) LoadAndValidate() error {
@@ -72,6 +86,14 @@ func (c *Config) LoadAndValidate() error {
 	if !validEndpoint {
 		return fmt.Errorf("Invalid endpoint type provided")
 	}
+
+	c.credentials = newCredentialChain(c)
+	if _, err := c.credentials.Get(); err != nil {
+		if err != errNoCredentials {
+			return fmt.Errorf("Error loading credentials: %s", err)
+		}
+		c.credentials = nil
+	}
 	// newhwClient(c) must be invoked at here, because newopenstackClient
 	// will use c.HwClient
 	err := newhwClient(c)
@@ -79,6 +101,10 @@ func (c *Config) LoadAndValidate() error {
 		return err
 	}
 
+	if err := c.validateRegion(); err != nil {
+		return err
+	}
+
 	return newopenstackClient(c)
 
 }
@@ -139,39 +165,34 @@ func newopenstackClient(c *Config) error {
 		config.BuildNameToCertificate()
 	}
 
-	// if OS_DEBUG is set, log the requests and responses
-	var osDebug bool
-	if os.Getenv("OS_DEBUG") != "" {
-		osDebug = true
-	}
-
 	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}
+	var rt http.RoundTripper = transport
+	if c.akskSigner != nil {
+		rt = &akskRoundTripper{
+			Rt:          transport,
+			Credentials: c.credentials,
+			ProjectID:   c.akskSigner.ProjectID,
+		}
+	}
+	lrt, err := newLogRoundTripper(rt)
+	if err != nil {
+		return err
+	}
 	client.HTTPClient = http.Client{
-		Transport: &LogRoundTripper{
-			Rt:      transport,
-			OsDebug: osDebug,
-		},
+		Transport: lrt,
 	}
 
 	// If using Swift Authentication, there's no need to validate authentication normally.
 	if !c.Swauth {
 		client.TokenID = c.HwClient.TokenID
-		client.EndpointLocator = func(opts gophercloud.EndpointOpts) (string, error) {
-			opts1 := golangsdk.EndpointOpts{
-				Type:         opts.Type,
-				Name:         opts.Name,
-				Region:       opts.Region,
-				Availability: golangsdk.Availability(string(opts.Availability)),
-			}
-			return c.HwClient.EndpointLocator(opts1)
-		}
+		client.EndpointLocator = c.osEndpointLocator
 	}
 
 	c.OsClient = client
 	//fmt.Printf("[DEBUG] Region: %s.\n", c.Region)
 
-	// Don't get session unless we need it for Accesskey, SecretKey.
-	if c.AccessKey != "" && c.SecretKey != "" {
+	// Don't get session unless we have an AK/SK for it.
+	if c.credentials != nil {
 		// Setup S3 client/config information for Swift S3 buckets
 		log.Println("[INFO] Building Swift S3 auth structure")
 		creds, err := GetCredentials(c)
@@ -197,7 +218,7 @@ func newopenstackClient(c *Config) error {
 			HTTPClient:  cleanhttp.DefaultClient(),
 		}
 
-		if osDebug {
+		if lrt.OsDebug {
 			sConfig.LogLevel = aws.LogLevel(aws.LogDebugWithHTTPBody | aws.LogDebugWithRequestRetries | aws.LogDebugWithRequestErrors)
 			sConfig.Logger = sLogger{}
 		}
@@ -278,23 +299,31 @@ func newhwClient(c *Config) error {
 		config.BuildNameToCertificate()
 	}
 
-	// if OS_DEBUG is set, log the requests and responses
-	var osDebug bool
-	if os.Getenv("OS_DEBUG") != "" {
-		osDebug = true
-	}
-
 	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}
+	c.transport = transport
+	var rt http.RoundTripper = transport
+	if c.useAKSKAuth() {
+		c.akskSigner = &akskRoundTripper{
+			Rt:          transport,
+			Credentials: c.credentials,
+		}
+		rt = c.akskSigner
+	}
+	lrt, err := newLogRoundTripper(rt)
+	if err != nil {
+		return err
+	}
 	client.HTTPClient = http.Client{
-		Transport: &LogRoundTripper{
-			Rt:      transport,
-			OsDebug: osDebug,
-		},
+		Transport: lrt,
 	}
 
 	// If using Swift Authentication, there's no need to validate authentication normally.
 	if !c.Swauth {
-		err = huaweisdk.Authenticate(client, ao)
+		if c.akskSigner != nil {
+			err = akskAuthenticate(client, c.akskSigner, c)
+		} else {
+			err = huaweisdk.Authenticate(client, ao)
+		}
 		if err != nil {
 			return err
 		}
@@ -305,6 +334,98 @@ func newhwClient(c *Config) error {
 	return nil
 }
 
+// validateRegion checks that the configured region is found in the service
+// catalog of the token, so that a typo or a region the project has no access
+// to fails at configure time rather than with a raw error from the first API
+// call. There is no catalog to check with Swift or AK/SK authentication.
+func (c *Config) validateRegion() error {
+	if c.Region == "" || c.Swauth || c.akskSigner != nil {
+		return nil
+	}
+
+	identity, err := huaweisdk.NewIdentityV3(c.HwClient, golangsdk.EndpointOpts{})
+	if err != nil {
+		log.Printf("[WARN] Unable to validate region %s: %s", c.Region, err)
+		return nil
+	}
+	catalog, err := tokens.Get(identity, c.HwClient.TokenID).ExtractServiceCatalog()
+	if err != nil {
+		log.Printf("[WARN] Unable to validate region %s: %s", c.Region, err)
+		return nil
+	}
+
+	return checkCatalogRegion(c.Region, catalog)
+}
+
+// checkCatalogRegion returns an error listing the valid regions if region is
+// not one of the regions of catalog.
+func checkCatalogRegion(region string, catalog *tokens.ServiceCatalog) error {
+	found := map[string]bool{}
+	for _, entry := range catalog.Entries {
+		for _, endpoint := range entry.Endpoints {
+			if endpoint.Region != "" {
+				found[endpoint.Region] = true
+			}
+		}
+	}
+
+	// Global-only catalogs, e.g. of domain scoped tokens, say nothing.
+	if len(found) == 0 || found[region] {
+		return nil
+	}
+
+	regions := make([]string, 0, len(found))
+	for r := range found {
+		regions = append(regions, r)
+	}
+	sort.Strings(regions)
+
+	return fmt.Errorf("Region %q is not available to the configured project, valid regions are: %s",
+		region, strings.Join(regions, ", "))
+}
+
+// newLogRoundTripper wraps rt for request logging as configured by the
+// environment: OS_DEBUG logs requests and responses, OS_DEBUG_SENSITIVE_FIELDS
+// adds comma-separated JSON keys to mask and OS_DEBUG_HAR records a HAR file.
+func newLogRoundTripper(rt http.RoundTripper) (*LogRoundTripper, error) {
+	lrt := &LogRoundTripper{
+		Rt:              rt,
+		OsDebug:         os.Getenv("OS_DEBUG") != "",
+		SensitiveFields: defaultSensitiveFields,
+	}
+
+	if v := os.Getenv("OS_DEBUG_SENSITIVE_FIELDS"); v != "" {
+		fields := append([]string{}, defaultSensitiveFields...)
+		for _, field := range strings.Split(v, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+		lrt.SensitiveFields = fields
+	}
+
+	if v := os.Getenv("OS_DEBUG_HAR"); v != "" {
+		har, err := getHarLogger(v)
+		if err != nil {
+			return nil, err
+		}
+		lrt.HarLog = har
+	}
+
+	return lrt, nil
+}
+
+// sensitiveLogLines matches the header lines of aws-sdk-go request dumps
+// which carry credentials.
+var sensitiveLogLines = regexp.MustCompile(`(?im)^((?:Authorization|X-Amz-Security-Token):).*$`)
+
+// useAKSKAuth reports whether the service clients sign their requests with
+// the AK/SK of the credential chain, which is the case when neither a
+// password nor a token is configured.
+func (c *Config) useAKSKAuth() bool {
+	return c.credentials != nil && c.Password == "" && c.Token == "" && !c.Swauth
+}
+
 type sLogger struct{}
 
 func (l sLogger) Log(args ...interface{}) {
@@ -314,7 +435,20 @@ func (l sLogger) Log(args ...interface{}) {
 			tokens = append(tokens, token)
 		}
 	}
-	log.Printf("[DEBUG] [aws-sdk-go] %s", strings.Join(tokens, " "))
+	message := sensitiveLogLines.ReplaceAllString(strings.Join(tokens, " "), "$1 ***")
+	log.Printf("[DEBUG] [aws-sdk-go] %s", message)
+}
+
+// osEndpointLocator looks up the endpoints of the gophercloud clients with
+// the locator of HwClient, so both share the catalog or the AK/SK templates.
+func (c *Config) osEndpointLocator(opts gophercloud.EndpointOpts) (string, error) {
+	opts1 := golangsdk.EndpointOpts{
+		Type:         opts.Type,
+		Name:         opts.Name,
+		Region:       opts.Region,
+		Availability: golangsdk.Availability(string(opts.Availability)),
+	}
+	return c.HwClient.EndpointLocator(opts1)
 }
 
 func (c *Config) determineRegion(region string) string {
@@ -439,6 +573,13 @@ func (c *Config) fwV2Client(region string) (*golangsdk.ServiceClient, error) {
 	})
 }
 
+func (c *Config) vpnV2Client(region string) (*golangsdk.ServiceClient, error) {
+	return huaweisdk.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
+		Region:       c.determineRegion(region),
+		Availability: c.getHwEndpointType(),
+	})
+}
+
 func (c *Config) loadElasticLoadBalancerClient(region string) (*golangsdk.ServiceClient, error) {
 	return huaweisdk.NewElasticLoadBalancer(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
@@ -453,6 +594,18 @@ func (c *Config) kmsKeyV1Client(region string) (*golangsdk.ServiceClient, error)
 	})
 }
 
+// kmsKeyPairV3Client is the client of the key pair service, it is served
+// next to the KMS v1.0 API.
+func (c *Config) kmsKeyPairV3Client(region string) (*golangsdk.ServiceClient, error) {
+	sc, err := c.kmsKeyV1Client(region)
+	if err != nil {
+		return nil, err
+	}
+	sc.Endpoint = strings.Replace(sc.Endpoint, "v1.0", "v3", 1)
+	sc.ResourceBase = sc.Endpoint
+	return sc, nil
+}
+
 func (c *Config) natV2Client(region string) (*golangsdk.ServiceClient, error) {
 	return huaweisdk.NewNatV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
@@ -519,6 +672,38 @@ func (c *Config) orchestrationV1Client(region string) (*golangsdk.ServiceClient,
 	})
 }
 
+// obsClient returns a client of the native OBS API, which is signed with the
+// access key rather than authenticated with the token.
+func (c *Config) obsClient(region string) (*obsClient, error) {
+	if c.credentials == nil {
+		return nil, fmt.Errorf("Missing credentials for OBS, need access_key and secret_key values for provider.")
+	}
+
+	region = c.determineRegion(region)
+	var endpoint string
+	sc, err := huaweisdk.NewOBSService(c.HwClient, golangsdk.EndpointOpts{
+		Region:       region,
+		Availability: c.getHwEndpointType(),
+	})
+	if err == nil {
+		endpoint = sc.Endpoint
+	} else {
+		// Not every catalog lists OBS, derive it from VPC as computeS3conn does.
+		nc, err := c.networkingHwV2Client(region)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = strings.Replace(nc.Endpoint, "//vpc", "//obs", 1)
+	}
+
+	lrt, err := newLogRoundTripper(c.transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOBSClient(endpoint, region, &http.Client{Transport: lrt}, c.credentials)
+}
+
 func (c *Config) networkingHwV2Client(region string) (*golangsdk.ServiceClient, error) {
 	return huaweisdk.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
@@ -0,0 +1,38 @@
+package huaweicloud
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+)
+
+func TestCheckCatalogRegion(t *testing.T) {
+	catalog := &tokens.ServiceCatalog{
+		Entries: []tokens.CatalogEntry{
+			{Type: "compute", Endpoints: []tokens.Endpoint{
+				{Region: "cn-north-1", Interface: "public"},
+				{Region: "cn-east-2", Interface: "public"},
+			}},
+			{Type: "identity", Endpoints: []tokens.Endpoint{
+				{Region: "", Interface: "public"},
+			}},
+		},
+	}
+
+	if err := checkCatalogRegion("cn-north-1", catalog); err != nil {
+		t.Fatalf("expected cn-north-1 to be valid, got: %s", err)
+	}
+
+	err := checkCatalogRegion("cn-nort-1", catalog)
+	if err == nil {
+		t.Fatal("expected cn-nort-1 to be rejected")
+	}
+	if !strings.Contains(err.Error(), "valid regions are: cn-east-2, cn-north-1") {
+		t.Fatalf("expected the valid regions in the error, got: %s", err)
+	}
+
+	if err := checkCatalogRegion("cn-nort-1", &tokens.ServiceCatalog{}); err != nil {
+		t.Fatalf("expected an empty catalog to skip validation, got: %s", err)
+	}
+}
@@ -0,0 +1,157 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/endpoints"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/services"
+)
+
+func dataSourceIdentityEndpointsV3() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIdentityEndpointsV3Read,
+
+		Schema: map[string]*schema.Schema{
+			"service_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"service_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"endpoint_region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"interface": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"public", "internal", "admin"})
+				},
+			},
+			"endpoints": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interface": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIdentityEndpointsV3Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	identityClient, err := config.loadIAMV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud identity client: %s", err)
+	}
+
+	serviceOpts := services.ListOpts{
+		ServiceType: d.Get("service_type").(string),
+		Name:        d.Get("service_name").(string),
+	}
+	allPages, err := services.List(identityClient, serviceOpts).AllPages()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve services: %s", err)
+	}
+
+	allServices, err := services.ExtractServices(allPages)
+	if err != nil {
+		return fmt.Errorf("Unable to extract services: %s", err)
+	}
+
+	serviceByID := make(map[string]services.Service, len(allServices))
+	for _, service := range allServices {
+		serviceByID[service.ID] = service
+	}
+
+	endpointOpts := endpoints.ListOpts{
+		Availability: golangsdk.Availability(d.Get("interface").(string)),
+	}
+	// Let the API filter by service when a single one matches.
+	if len(allServices) == 1 {
+		endpointOpts.ServiceID = allServices[0].ID
+	}
+	allPages, err = endpoints.List(identityClient, endpointOpts).AllPages()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve endpoints: %s", err)
+	}
+
+	allEndpoints, err := endpoints.ExtractEndpoints(allPages)
+	if err != nil {
+		return fmt.Errorf("Unable to extract endpoints: %s", err)
+	}
+
+	region := d.Get("endpoint_region").(string)
+	var ids []string
+	result := make([]map[string]interface{}, 0, len(allEndpoints))
+	for _, endpoint := range allEndpoints {
+		service, ok := serviceByID[endpoint.ServiceID]
+		if !ok {
+			continue
+		}
+		if region != "" && endpoint.Region != region {
+			continue
+		}
+
+		serviceName, _ := service.Extra["name"].(string)
+		ids = append(ids, endpoint.ID)
+		result = append(result, map[string]interface{}{
+			"id":           endpoint.ID,
+			"service_id":   endpoint.ServiceID,
+			"service_name": serviceName,
+			"service_type": service.Type,
+			"region":       endpoint.Region,
+			"interface":    string(endpoint.Availability),
+			"url":          endpoint.URL,
+		})
+	}
+
+	log.Printf("[DEBUG] Retrieved %d endpoints", len(result))
+
+	sort.Strings(ids)
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+	if err := d.Set("endpoints", result); err != nil {
+		return fmt.Errorf("Unable to set endpoints: %s", err)
+	}
+
+	return nil
+}
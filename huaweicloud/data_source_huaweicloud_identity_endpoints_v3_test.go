@@ -0,0 +1,53 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccIdentityEndpointsV3DataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccIdentityEndpointsV3DataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIdentityEndpointsV3DataSourceID("data.huaweicloud_identity_endpoints_v3.compute"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_identity_endpoints_v3.compute", "endpoints.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_identity_endpoints_v3.compute", "endpoints.0.service_type", "compute"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_identity_endpoints_v3.compute", "endpoints.0.region", OS_REGION_NAME),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIdentityEndpointsV3DataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find endpoints data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Endpoints data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+var testAccIdentityEndpointsV3DataSource_basic = fmt.Sprintf(`
+data "huaweicloud_identity_endpoints_v3" "compute" {
+  service_type    = "compute"
+  endpoint_region = "%s"
+  interface       = "public"
+}
+`, OS_REGION_NAME)
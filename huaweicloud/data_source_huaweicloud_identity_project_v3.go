@@ -0,0 +1,125 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/projects"
+)
+
+func dataSourceIdentityProjectV3() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIdentityProjectV3Read,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"domain_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"parent_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_domain": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// dataSourceIdentityProjectV3Read looks up a project by ID or by the given
+// filters. Without any, the project the provider is scoped to is returned.
+func dataSourceIdentityProjectV3Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	identityClient, err := config.loadIAMV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud identity client: %s", err)
+	}
+
+	var project *projects.Project
+	if id, ok := d.GetOk("project_id"); ok {
+		project, err = projects.Get(identityClient, id.(string)).Extract()
+		if err != nil {
+			return fmt.Errorf("Unable to retrieve project %s: %s", id, err)
+		}
+	} else {
+		listOpts := projects.ListOpts{
+			Name:     d.Get("name").(string),
+			DomainID: d.Get("domain_id").(string),
+			ParentID: d.Get("parent_id").(string),
+		}
+
+		if listOpts == (projects.ListOpts{}) {
+			if config.TenantID != "" {
+				project, err = projects.Get(identityClient, config.TenantID).Extract()
+				if err != nil {
+					return fmt.Errorf("Unable to retrieve project %s: %s", config.TenantID, err)
+				}
+			} else if config.TenantName != "" {
+				listOpts.Name = config.TenantName
+			} else {
+				listOpts.Name = config.Region
+			}
+		}
+
+		if project == nil {
+			allPages, err := projects.List(identityClient, listOpts).AllPages()
+			if err != nil {
+				return fmt.Errorf("Unable to retrieve projects: %s", err)
+			}
+
+			allProjects, err := projects.ExtractProjects(allPages)
+			if err != nil {
+				return fmt.Errorf("Unable to extract projects: %s", err)
+			}
+
+			if len(allProjects) < 1 {
+				return fmt.Errorf("Your query returned no results. " +
+					"Please change your search criteria and try again.")
+			}
+
+			if len(allProjects) > 1 {
+				log.Printf("[DEBUG] Multiple results found: %#v", allProjects)
+				return fmt.Errorf("Your query returned more than one result. " +
+					"Please try a more specific search criteria.")
+			}
+
+			project = &allProjects[0]
+		}
+	}
+
+	log.Printf("[DEBUG] Retrieved project %s: %+v", project.ID, project)
+
+	d.SetId(project.ID)
+	d.Set("project_id", project.ID)
+	d.Set("name", project.Name)
+	d.Set("domain_id", project.DomainID)
+	d.Set("parent_id", project.ParentID)
+	d.Set("description", project.Description)
+	d.Set("enabled", project.Enabled)
+	d.Set("is_domain", project.IsDomain)
+
+	return nil
+}
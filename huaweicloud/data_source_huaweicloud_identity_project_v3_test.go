@@ -0,0 +1,49 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccIdentityProjectV3DataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccIdentityProjectV3DataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIdentityProjectV3DataSourceID("data.huaweicloud_identity_project_v3.project_1"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_identity_project_v3.project_1", "name", OS_REGION_NAME),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_identity_project_v3.project_1", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIdentityProjectV3DataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find project data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Project data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+var testAccIdentityProjectV3DataSource_basic = fmt.Sprintf(`
+data "huaweicloud_identity_project_v3" "project_1" {
+  name = "%s"
+}
+`, OS_REGION_NAME)
@@ -0,0 +1,161 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+func dataSourceNetworkingPortV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNetworkingPortV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"port_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"network_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"device_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"device_owner": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"mac_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"fixed_ip": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIP,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"admin_state_up": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"all_fixed_ips": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"all_security_group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func dataSourceNetworkingPortV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	listOpts := ports.ListOpts{
+		ID:          d.Get("port_id").(string),
+		Name:        d.Get("name").(string),
+		NetworkID:   d.Get("network_id").(string),
+		DeviceID:    d.Get("device_id").(string),
+		DeviceOwner: d.Get("device_owner").(string),
+		MACAddress:  d.Get("mac_address").(string),
+		Status:      d.Get("status").(string),
+		TenantID:    d.Get("tenant_id").(string),
+	}
+
+	pages, err := ports.List(networkingClient, listOpts).AllPages()
+	if err != nil {
+		return err
+	}
+
+	allPorts, err := ports.ExtractPorts(pages)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve ports: %s", err)
+	}
+
+	var refinedPorts []ports.Port
+	if fixedIP := d.Get("fixed_ip").(string); fixedIP != "" {
+		for _, p := range allPorts {
+			for _, ip := range p.FixedIPs {
+				if ip.IPAddress == fixedIP {
+					refinedPorts = append(refinedPorts, p)
+					break
+				}
+			}
+		}
+	} else {
+		refinedPorts = allPorts
+	}
+
+	if len(refinedPorts) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedPorts) > 1 {
+		return fmt.Errorf("Your query returned more than one result." +
+			" Please try a more specific search criteria")
+	}
+
+	port := refinedPorts[0]
+
+	log.Printf("[DEBUG] Retrieved Port %s: %+v", port.ID, port)
+	d.SetId(port.ID)
+
+	var ips []string
+	for _, ipObject := range port.FixedIPs {
+		ips = append(ips, ipObject.IPAddress)
+	}
+
+	d.Set("port_id", port.ID)
+	d.Set("name", port.Name)
+	d.Set("network_id", port.NetworkID)
+	d.Set("device_id", port.DeviceID)
+	d.Set("device_owner", port.DeviceOwner)
+	d.Set("mac_address", port.MACAddress)
+	d.Set("status", port.Status)
+	d.Set("tenant_id", port.TenantID)
+	d.Set("admin_state_up", port.AdminStateUp)
+	d.Set("all_fixed_ips", ips)
+	d.Set("all_security_group_ids", port.SecurityGroups)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccHuaweiCloudNetworkingPortV2DataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccHuaweiCloudNetworkingPortV2DataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingPortV2DataSourceID("data.huaweicloud_networking_port_v2.port_1"),
+					resource.TestCheckResourceAttrPair(
+						"data.huaweicloud_networking_port_v2.port_1", "id",
+						"huaweicloud_networking_port_v2.port_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_networking_port_v2.port_1", "name", "port_1"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_networking_port_v2.port_1", "all_fixed_ips.0", "192.168.199.23"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingPortV2DataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find port data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Port data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+const testAccHuaweiCloudNetworkingPortV2DataSource_basic = `
+resource "huaweicloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "huaweicloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+}
+
+resource "huaweicloud_networking_port_v2" "port_1" {
+  name = "port_1"
+  admin_state_up = "true"
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+
+  fixed_ip {
+    subnet_id =  "${huaweicloud_networking_subnet_v2.subnet_1.id}"
+    ip_address = "192.168.199.23"
+  }
+}
+
+data "huaweicloud_networking_port_v2" "port_1" {
+  network_id = "${huaweicloud_networking_port_v2.port_1.network_id}"
+  fixed_ip = "192.168.199.23"
+}
+`
@@ -0,0 +1,131 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+)
+
+func dataSourceNetworkingSecGroupRulesV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNetworkingSecGroupRulesV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"security_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"direction": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"ingress", "egress"})
+				},
+			},
+			"ethertype": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"IPv4", "IPv6"})
+				},
+			},
+			"protocol": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"remote_ip_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"remote_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rules": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"direction": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ethertype": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port_range_min": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"port_range_max": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"remote_ip_prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"remote_group_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkingSecGroupRulesV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	secGroupID := d.Get("security_group_id").(string)
+	listOpts := rules.ListOpts{
+		SecGroupID:     secGroupID,
+		Direction:      d.Get("direction").(string),
+		EtherType:      d.Get("ethertype").(string),
+		Protocol:       strings.ToLower(d.Get("protocol").(string)),
+		RemoteIPPrefix: d.Get("remote_ip_prefix").(string),
+		RemoteGroupID:  d.Get("remote_group_id").(string),
+	}
+
+	list, err := listSecGroupRules(networkingClient, listOpts)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve the rules of security group %s: %s", secGroupID, err)
+	}
+	log.Printf("[DEBUG] Retrieved rules of security group %s: %+v", secGroupID, list)
+
+	d.SetId(secGroupID)
+	d.Set("region", GetRegion(d, config))
+	if err := d.Set("rules", flattenSecGroupRules(list)); err != nil {
+		return fmt.Errorf("Error setting rules: %s", err)
+	}
+
+	return nil
+}
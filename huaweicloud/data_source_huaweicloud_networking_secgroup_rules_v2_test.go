@@ -0,0 +1,55 @@
+package huaweicloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccHuaweiCloudNetworkingSecGroupRulesV2DataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccHuaweiCloudNetworkingSecGroupRulesV2DataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_networking_secgroup_rules_v2.rules_1", "rules.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_networking_secgroup_rules_v2.rules_1", "rules.0.port_range_min", "22"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_networking_secgroup_rules_v2.rules_1", "rules.0.description", "ssh"),
+				),
+			},
+		},
+	})
+}
+
+const testAccHuaweiCloudNetworkingSecGroupRulesV2DataSource_basic = `
+resource "huaweicloud_networking_secgroup_v2" "secgroup_1" {
+  name = "secgroup_1"
+  description = "My neutron security group"
+
+  rule {
+    direction = "ingress"
+    ethertype = "IPv4"
+    protocol = "tcp"
+    port_range_min = 22
+    port_range_max = 22
+    remote_ip_prefix = "0.0.0.0/0"
+    description = "ssh"
+  }
+
+  rule {
+    direction = "egress"
+    ethertype = "IPv4"
+  }
+}
+
+data "huaweicloud_networking_secgroup_rules_v2" "rules_1" {
+  security_group_id = "${huaweicloud_networking_secgroup_v2.secgroup_1.id}"
+  direction = "ingress"
+  ethertype = "IPv4"
+}
+`
@@ -0,0 +1,102 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/regions"
+)
+
+func dataSourceRegions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRegionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"parent_region_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"regions": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_region_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRegionsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	identityClient, err := config.loadIAMV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud identity client: %s", err)
+	}
+
+	listOpts := regions.ListOpts{
+		ParentRegionID: d.Get("parent_region_id").(string),
+	}
+
+	allPages, err := regions.List(identityClient, listOpts).AllPages()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve regions: %s", err)
+	}
+
+	allRegions, err := regions.ExtractRegions(allPages)
+	if err != nil {
+		return fmt.Errorf("Unable to extract regions: %s", err)
+	}
+
+	log.Printf("[DEBUG] Retrieved regions: %+v", allRegions)
+
+	sort.Slice(allRegions, func(i, j int) bool { return allRegions[i].ID < allRegions[j].ID })
+
+	names := make([]string, 0, len(allRegions))
+	result := make([]map[string]interface{}, 0, len(allRegions))
+	for _, region := range allRegions {
+		names = append(names, region.ID)
+
+		regionType, _ := region.Extra["type"].(string)
+		result = append(result, map[string]interface{}{
+			"id":               region.ID,
+			"description":      region.Description,
+			"parent_region_id": region.ParentRegionID,
+			"type":             regionType,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(names, ","))))
+	d.Set("names", names)
+	if err := d.Set("regions", result); err != nil {
+		return fmt.Errorf("Unable to set regions: %s", err)
+	}
+
+	return nil
+}
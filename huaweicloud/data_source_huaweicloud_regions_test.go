@@ -0,0 +1,51 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccHuaweiCloudRegionsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccHuaweiCloudRegionsDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHuaweiCloudRegionsDataSourceContains("data.huaweicloud_regions.regions", OS_REGION_NAME),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHuaweiCloudRegionsDataSourceContains(n, region string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find regions data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Regions data source ID not set")
+		}
+
+		for k, v := range rs.Primary.Attributes {
+			if k != "names.#" && strings.HasPrefix(k, "names.") && v == region {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Region %s not found in %s", region, n)
+	}
+}
+
+const testAccHuaweiCloudRegionsDataSource_basic = `
+data "huaweicloud_regions" "regions" {
+}
+`
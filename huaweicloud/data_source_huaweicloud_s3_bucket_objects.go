@@ -0,0 +1,290 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// s3ListMaxKeys is the largest number of keys returned by a listing request.
+const s3ListMaxKeys = 1000
+
+func dataSourceS3BucketObjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceS3BucketObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"delimiter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"marker": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_keys": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1000,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateIntRange(v, k, 1, 100000)
+				},
+			},
+			"key_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegexp,
+			},
+			"versions": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"keys": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"common_prefixes": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"latest_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"objects": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"etag": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"last_modified": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_class": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_latest": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// s3ListedObject is an object or an object version returned by a listing.
+type s3ListedObject struct {
+	Key          string
+	ETag         string
+	Size         int64
+	LastModified time.Time
+	StorageClass string
+	VersionID    string
+	IsLatest     bool
+}
+
+func dataSourceS3BucketObjectsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	conn, err := config.computeS3conn(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+	delimiter := d.Get("delimiter").(string)
+	marker := d.Get("marker").(string)
+	maxKeys := d.Get("max_keys").(int)
+
+	// The keys are filtered while paging, so that max_keys applies to the
+	// matching keys.
+	var keyRegex *regexp.Regexp
+	if v, ok := d.GetOk("key_regex"); ok {
+		keyRegex = regexp.MustCompile(v.(string))
+	}
+	matches := func(key string) bool { return keyRegex == nil || keyRegex.MatchString(key) }
+
+	var objects []s3ListedObject
+	var prefixes []string
+	// A page holds both keys and common prefixes, max_keys applies to both.
+	remaining := func() int { return maxKeys - len(objects) - len(prefixes) }
+	pageSize := func() *int64 {
+		if n := remaining(); n < s3ListMaxKeys && keyRegex == nil {
+			return aws.Int64(int64(n))
+		}
+		return aws.Int64(s3ListMaxKeys)
+	}
+
+	if d.Get("versions").(bool) {
+		input := &s3.ListObjectVersionsInput{
+			Bucket:  aws.String(bucket),
+			MaxKeys: pageSize(),
+		}
+		if prefix != "" {
+			input.Prefix = aws.String(prefix)
+		}
+		if delimiter != "" {
+			input.Delimiter = aws.String(delimiter)
+		}
+		if marker != "" {
+			input.KeyMarker = aws.String(marker)
+		}
+
+		log.Printf("[DEBUG] Listing S3 object versions: %s", input)
+		err = conn.ListObjectVersionsPages(input, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+			for _, v := range page.Versions {
+				if remaining() <= 0 {
+					return false
+				}
+				if !matches(aws.StringValue(v.Key)) {
+					continue
+				}
+				objects = append(objects, s3ListedObject{
+					Key:          aws.StringValue(v.Key),
+					ETag:         strings.Trim(aws.StringValue(v.ETag), `"`),
+					Size:         aws.Int64Value(v.Size),
+					LastModified: aws.TimeValue(v.LastModified),
+					StorageClass: aws.StringValue(v.StorageClass),
+					VersionID:    aws.StringValue(v.VersionId),
+					IsLatest:     aws.BoolValue(v.IsLatest),
+				})
+			}
+			for _, p := range page.CommonPrefixes {
+				if remaining() <= 0 {
+					return false
+				}
+				prefixes = append(prefixes, aws.StringValue(p.Prefix))
+			}
+			return remaining() > 0
+		})
+	} else {
+		input := &s3.ListObjectsInput{
+			Bucket:  aws.String(bucket),
+			MaxKeys: pageSize(),
+		}
+		if prefix != "" {
+			input.Prefix = aws.String(prefix)
+		}
+		if delimiter != "" {
+			input.Delimiter = aws.String(delimiter)
+		}
+		if marker != "" {
+			input.Marker = aws.String(marker)
+		}
+
+		log.Printf("[DEBUG] Listing S3 objects: %s", input)
+		err = conn.ListObjectsPages(input, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+			for _, o := range page.Contents {
+				if remaining() <= 0 {
+					return false
+				}
+				if !matches(aws.StringValue(o.Key)) {
+					continue
+				}
+				objects = append(objects, s3ListedObject{
+					Key:          aws.StringValue(o.Key),
+					ETag:         strings.Trim(aws.StringValue(o.ETag), `"`),
+					Size:         aws.Int64Value(o.Size),
+					LastModified: aws.TimeValue(o.LastModified),
+					StorageClass: aws.StringValue(o.StorageClass),
+					IsLatest:     true,
+				})
+			}
+			for _, p := range page.CommonPrefixes {
+				if remaining() <= 0 {
+					return false
+				}
+				prefixes = append(prefixes, aws.StringValue(p.Prefix))
+			}
+			return remaining() > 0
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("Error listing objects of S3 bucket (%s): %s", bucket, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", bucket, hashcode.String(strings.Join([]string{
+		prefix, delimiter, marker, fmt.Sprintf("%d", maxKeys), d.Get("key_regex").(string),
+		fmt.Sprintf("%t", d.Get("versions").(bool))}, "|"))))
+
+	keys := make([]string, 0, len(objects))
+	flattened := make([]map[string]interface{}, 0, len(objects))
+	for _, o := range objects {
+		keys = append(keys, o.Key)
+		flattened = append(flattened, map[string]interface{}{
+			"key":           o.Key,
+			"etag":          o.ETag,
+			"size":          int(o.Size),
+			"last_modified": o.LastModified.Format(time.RFC1123),
+			"storage_class": o.StorageClass,
+			"version_id":    o.VersionID,
+			"is_latest":     o.IsLatest,
+		})
+	}
+	if prefixes == nil {
+		prefixes = []string{}
+	}
+
+	d.Set("keys", keys)
+	d.Set("common_prefixes", prefixes)
+	d.Set("latest_key", latestS3ListedObjectKey(objects))
+	if err := d.Set("objects", flattened); err != nil {
+		return fmt.Errorf("Error setting objects: %s", err)
+	}
+
+	return nil
+}
+
+// latestS3ListedObjectKey returns the key of the most recently modified
+// object, the greatest key among the objects modified at the same time.
+func latestS3ListedObjectKey(objects []s3ListedObject) string {
+	var latest *s3ListedObject
+	for i := range objects {
+		o := &objects[i]
+		if latest == nil || o.LastModified.After(latest.LastModified) ||
+			(o.LastModified.Equal(latest.LastModified) && o.Key > latest.Key) {
+			latest = o
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Key
+}
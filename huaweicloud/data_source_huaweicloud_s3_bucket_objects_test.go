@@ -0,0 +1,116 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceS3BucketObjects_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceOnlyConf, conf := testAccDataSourceS3ObjectsConfig_basic(rInt)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                  func() { testAccPreCheck(t) },
+		Providers:                 testAccProviders,
+		PreventPostDestroyRefresh: true,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: resourceOnlyConf,
+			},
+			resource.TestStep{
+				Config: conf,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.all", "keys.#", "3"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.all", "objects.0.key", "tenants/a/one"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.all", "objects.0.size", "3"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.tenants", "keys.#", "0"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.tenants", "common_prefixes.#", "2"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.tenants", "common_prefixes.0", "tenants/a/"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.filtered", "keys.#", "2"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.limited", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.filtered_limited", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.huaweicloud_s3_bucket_objects.filtered_limited", "keys.0", "tenants/a/two"),
+				),
+			},
+		},
+	})
+}
+
+func TestLatestS3ListedObjectKey(t *testing.T) {
+	now := time.Now()
+	objects := []s3ListedObject{
+		{Key: "app-1.0.tar.gz", LastModified: now.Add(-time.Hour)},
+		{Key: "app-1.1.tar.gz", LastModified: now},
+		{Key: "app-1.2.tar.gz", LastModified: now},
+		{Key: "app-0.9.tar.gz", LastModified: now.Add(-2 * time.Hour)},
+	}
+
+	if key := latestS3ListedObjectKey(objects); key != "app-1.2.tar.gz" {
+		t.Fatalf("expected app-1.2.tar.gz, got %s", key)
+	}
+
+	if key := latestS3ListedObjectKey(objects[:1]); key != "app-1.0.tar.gz" {
+		t.Fatalf("expected app-1.0.tar.gz, got %s", key)
+	}
+
+	if key := latestS3ListedObjectKey(nil); key != "" {
+		t.Fatalf("expected no key, got %s", key)
+	}
+}
+
+func testAccDataSourceS3ObjectsConfig_basic(randInt int) (string, string) {
+	resources := fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "objects_bucket" {
+	bucket = "tf-objects-test-bucket-%d"
+}
+resource "huaweicloud_s3_bucket_object" "a_one" {
+	bucket = "${huaweicloud_s3_bucket.objects_bucket.bucket}"
+	key = "tenants/a/one"
+	content = "one"
+}
+resource "huaweicloud_s3_bucket_object" "a_two" {
+	bucket = "${huaweicloud_s3_bucket.objects_bucket.bucket}"
+	key = "tenants/a/two"
+	content = "two"
+}
+resource "huaweicloud_s3_bucket_object" "b_one" {
+	bucket = "${huaweicloud_s3_bucket.objects_bucket.bucket}"
+	key = "tenants/b/one"
+	content = "one"
+}
+`, randInt)
+
+	both := fmt.Sprintf(`%s
+data "huaweicloud_s3_bucket_objects" "all" {
+	bucket = "tf-objects-test-bucket-%d"
+}
+
+data "huaweicloud_s3_bucket_objects" "tenants" {
+	bucket = "tf-objects-test-bucket-%d"
+	prefix = "tenants/"
+	delimiter = "/"
+}
+
+data "huaweicloud_s3_bucket_objects" "filtered" {
+	bucket = "tf-objects-test-bucket-%d"
+	key_regex = "/one$"
+}
+
+data "huaweicloud_s3_bucket_objects" "limited" {
+	bucket = "tf-objects-test-bucket-%d"
+	max_keys = 1
+}
+
+data "huaweicloud_s3_bucket_objects" "filtered_limited" {
+	bucket = "tf-objects-test-bucket-%d"
+	key_regex = "/two$"
+	max_keys = 1
+}
+`, resources, randInt, randInt, randInt, randInt, randInt)
+
+	return resources, both
+}
@@ -0,0 +1,110 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceSMNSubscriptionsV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSMNSubscriptionsV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"topic_urn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"protocol": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  -1,
+			},
+			"subscriptions": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subscription_urn": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"endpoint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"remark": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSMNSubscriptionsV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.SmnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+	}
+
+	topicUrn := d.Get("topic_urn").(string)
+	list, err := listSMNTopicSubscriptions(client, topicUrn)
+	if err != nil {
+		return fmt.Errorf("Error listing subscriptions of topic %s: %s", topicUrn, err)
+	}
+	log.Printf("[DEBUG] Retrieved subscriptions of topic %s: %#v", topicUrn, list)
+
+	protocol := d.Get("protocol").(string)
+	status := d.Get("status").(int)
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, s := range list {
+		if protocol != "" && s.Protocol != protocol {
+			continue
+		}
+		if status >= 0 && s.Status != status {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"subscription_urn": s.SubscriptionUrn,
+			"endpoint":         s.Endpoint,
+			"protocol":         s.Protocol,
+			"owner":            s.Owner,
+			"remark":           s.Remark,
+			"status":           s.Status,
+		})
+	}
+
+	d.SetId(topicUrn)
+	d.Set("region", GetRegion(d, config))
+	if err := d.Set("subscriptions", result); err != nil {
+		return fmt.Errorf("Error setting subscriptions: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package huaweicloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSMNV2SubscriptionsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSMNV2SubscriptionsDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_smn_subscriptions_v2.all", "subscriptions.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_smn_subscriptions_v2.sms", "subscriptions.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_smn_subscriptions_v2.sms", "subscriptions.0.endpoint", "13600000000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSMNV2SubscriptionsDataSource_basic = `
+resource "huaweicloud_smn_topic_v2" "topic_1" {
+  name            = "topic_1"
+  display_name    = "The display name of topic_1"
+}
+
+resource "huaweicloud_smn_subscription_v2" "subscription_1" {
+  topic_urn       = "${huaweicloud_smn_topic_v2.topic_1.id}"
+  endpoint        = "mailtest@gmail.com"
+  protocol        = "email"
+  remark          = "O&M"
+}
+
+resource "huaweicloud_smn_subscription_v2" "subscription_2" {
+  topic_urn       = "${huaweicloud_smn_topic_v2.topic_1.id}"
+  endpoint        = "13600000000"
+  protocol        = "sms"
+  remark          = "O&M"
+}
+
+data "huaweicloud_smn_subscriptions_v2" "all" {
+  topic_urn  = "${huaweicloud_smn_topic_v2.topic_1.id}"
+  depends_on = ["huaweicloud_smn_subscription_v2.subscription_1", "huaweicloud_smn_subscription_v2.subscription_2"]
+}
+
+data "huaweicloud_smn_subscriptions_v2" "sms" {
+  topic_urn  = "${huaweicloud_smn_topic_v2.topic_1.id}"
+  protocol   = "sms"
+  depends_on = ["huaweicloud_smn_subscription_v2.subscription_2"]
+}
+`
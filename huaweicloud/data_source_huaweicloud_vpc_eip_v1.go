@@ -0,0 +1,111 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/eips"
+)
+
+func dataSourceVpcEIPV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVpcEIPV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"public_ip": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateIP,
+			},
+			"port_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bandwidth_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bandwidth_size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bandwidth_share_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVpcEIPV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	allEIPs, err := listVpcEIPs(networkingClient)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve EIPs: %s", err)
+	}
+
+	publicIP := d.Get("public_ip").(string)
+	portID := d.Get("port_id").(string)
+	var refinedEIPs []eips.PublicIp
+	for _, eip := range allEIPs {
+		if publicIP != "" && eip.PublicAddress != publicIP {
+			continue
+		}
+		if portID != "" && eip.PortID != portID {
+			continue
+		}
+		refinedEIPs = append(refinedEIPs, eip)
+	}
+
+	if len(refinedEIPs) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedEIPs) > 1 {
+		return fmt.Errorf("Your query returned more than one result." +
+			" Please try a more specific search criteria")
+	}
+
+	eip := refinedEIPs[0]
+
+	log.Printf("[DEBUG] Retrieved EIP %s: %+v", eip.ID, eip)
+	d.SetId(eip.ID)
+
+	d.Set("public_ip", eip.PublicAddress)
+	d.Set("port_id", eip.PortID)
+	d.Set("status", eip.Status)
+	d.Set("type", eip.Type)
+	d.Set("private_ip", eip.PrivateAddress)
+	d.Set("bandwidth_id", eip.BandwidthID)
+	d.Set("bandwidth_size", eip.BandwidthSize)
+	d.Set("bandwidth_share_type", eip.BandwidthShareType)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
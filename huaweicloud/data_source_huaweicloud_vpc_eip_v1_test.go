@@ -0,0 +1,62 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVpcEIPV1DataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEIPV1DataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEIPV1DataSourceID("data.huaweicloud_vpc_eip_v1.eip_1"),
+					resource.TestCheckResourceAttrPair(
+						"data.huaweicloud_vpc_eip_v1.eip_1", "id",
+						"huaweicloud_vpc_eip_v1.eip_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.huaweicloud_vpc_eip_v1.eip_1", "bandwidth_size", "8"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcEIPV1DataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find EIP data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("EIP data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+const testAccVpcEIPV1DataSource_basic = `
+resource "huaweicloud_vpc_eip_v1" "eip_1" {
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    name = "test"
+    size = 8
+    share_type = "PER"
+    charge_mode = "traffic"
+  }
+}
+
+data "huaweicloud_vpc_eip_v1" "eip_1" {
+  public_ip = "${huaweicloud_vpc_eip_v1.eip_1.publicip.0.ip_address}"
+}
+`
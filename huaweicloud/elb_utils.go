@@ -1,6 +1,7 @@
 package huaweicloud
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -13,28 +14,27 @@ import (
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/elb/loadbalancers"
 )
 
-func waitForELBJobSuccess(networkingClient *golangsdk.ServiceClient, j *elb.Job, timeout time.Duration) (*elb.JobInfo, error) {
-	jobId := j.JobId
-	target := "SUCCESS"
-	pending := []string{"INIT", "RUNNING"}
-
-	log.Printf("[DEBUG] Waiting for elb job %s to become %s.", jobId, target)
-
-	ji, err := waitForELBResource(networkingClient, "job", j.Uri, target, pending, timeout, getELBJobInfo)
-	if err == nil {
-		return ji.(*elb.JobInfo), nil
+// newELBJobTracker returns the tracker of the jobs of the classic ELB service.
+func newELBJobTracker(networkingClient *golangsdk.ServiceClient) *jobTracker {
+	return &jobTracker{
+		Service: "elb",
+		Query: func(uri string) (*jobStatus, error) {
+			s := &jobStatus{}
+			err := elb.QueryJobInfo(networkingClient, uri).ExtractInto(s)
+			return s, err
+		},
 	}
-	return nil, err
 }
 
-func getELBJobInfo(networkingClient *golangsdk.ServiceClient, uri string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		info, err := elb.QueryJobInfo(networkingClient, uri).Extract()
+// elbJob adapts the classic ELB API calls, which return an elb.Job, to
+// jobTracker.Run and jobTracker.RunWithRetry.
+func elbJob(f func() (*elb.Job, error)) jobSubmitFunc {
+	return func() (*golangsdk.JobResponse, error) {
+		j, err := f()
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
-
-		return info, info.Status, nil
+		return &golangsdk.JobResponse{URI: j.Uri, JobID: j.JobId}, nil
 	}
 }
 
@@ -79,25 +79,10 @@ func getELBListener(networkingClient *golangsdk.ServiceClient, id string) resour
 type getELBResource func(networkingClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc
 
 func waitForELBResource(networkingClient *golangsdk.ServiceClient, name string, id string, target string, pending []string, timeout time.Duration, f getELBResource) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    f(networkingClient, id),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	o, err := stateConf.WaitForState()
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			return nil, fmt.Errorf("Error: elb %s %s not found: %s", name, id, err)
-		}
-		return nil, fmt.Errorf("Error waiting for elb %s %s to become %s: %s", name, id, target, err)
-	}
-
-	return o, nil
+	return waitForStatus(ctx, fmt.Sprintf("elb %s %s", name, id), pending, []string{target}, f(networkingClient, id))
 }
 
 func chooseELBClient(d *schema.ResourceData, config *Config) (*golangsdk.ServiceClient, error) {
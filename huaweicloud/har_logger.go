@@ -0,0 +1,220 @@
+package huaweicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Unknwon/com"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// harTrailer closes the entries array and the log object of a HAR file.
+// It is rewritten after every entry so the file is always valid JSON.
+const harTrailer = "]}}\n"
+
+// harLogger appends request/response pairs to a HAR 1.2 file. The HAR
+// format is understood by browsers and most HTTP tooling, which makes it a
+// convenient way to share a trace of the provider's API calls.
+type harLogger struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries int
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	Error       string         `json:"_error,omitempty"`
+}
+
+type harTimings struct {
+	Send    int `json:"send"`
+	Wait    int `json:"wait"`
+	Receive int `json:"receive"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int         `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	RequestID       string      `json:"_requestId"`
+	ServerRequestID string      `json:"_serverRequestId,omitempty"`
+}
+
+var (
+	harLoggersMu sync.Mutex
+	harLoggers   = map[string]*harLogger{}
+)
+
+// getHarLogger returns the HAR logger writing to path, creating the file on
+// first use. The identity and the service clients share one logger so that
+// the whole run ends up in a single file.
+func getHarLogger(path string) (*harLogger, error) {
+	harLoggersMu.Lock()
+	defer harLoggersMu.Unlock()
+
+	if l, ok := harLoggers[path]; ok {
+		return l, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating HAR file %s: %s", path, err)
+	}
+
+	header := fmt.Sprintf(`{"log":{"version":"1.2","creator":{"name":"Terraform","version":%q},"entries":[`,
+		terraform.VersionString())
+	if _, err := f.WriteString(header + harTrailer); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("Error writing HAR file %s: %s", path, err)
+	}
+
+	l := &harLogger{file: f}
+	harLoggers[path] = l
+	return l, nil
+}
+
+// Record appends one request/response pair. The bodies passed in must
+// already be redacted; headers are redacted here. Failures to write are
+// logged rather than returned so that they never break an API call.
+func (l *harLogger) Record(reqID string, started time.Time, elapsed time.Duration,
+	request *http.Request, reqBody string, response *http.Response, respBody string, rtErr error) {
+	ms := int(elapsed / time.Millisecond)
+	entry := harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      request.Method,
+			URL:         request.URL.String(),
+			HTTPVersion: request.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(request.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings:   harTimings{Send: 0, Wait: ms, Receive: 0},
+		RequestID: reqID,
+	}
+
+	for name, values := range request.URL.Query() {
+		for _, v := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: v})
+		}
+	}
+
+	if reqBody != "" {
+		entry.Request.PostData = &harPostData{
+			MimeType: request.Header.Get("Content-Type"),
+			Text:     reqBody,
+		}
+	}
+
+	if response != nil {
+		entry.Response.Status = response.StatusCode
+		entry.Response.StatusText = http.StatusText(response.StatusCode)
+		entry.Response.HTTPVersion = response.Proto
+		entry.Response.Headers = harHeaders(response.Header)
+		entry.Response.Content = harContent{
+			Size:     len(respBody),
+			MimeType: response.Header.Get("Content-Type"),
+			Text:     respBody,
+		}
+		entry.ServerRequestID = responseRequestID(response.Header)
+	}
+
+	if rtErr != nil {
+		entry.Response.Error = rtErr.Error()
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] Unable to marshal HAR entry %s: %s", reqID, err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Overwrite the trailer with the new entry and write it back after.
+	if _, err := l.file.Seek(-int64(len(harTrailer)), io.SeekEnd); err != nil {
+		log.Printf("[WARN] Unable to write HAR entry %s: %s", reqID, err)
+		return
+	}
+	if l.entries > 0 {
+		b = append([]byte(","), b...)
+	}
+	if _, err := l.file.Write(append(b, harTrailer...)); err != nil {
+		log.Printf("[WARN] Unable to write HAR entry %s: %s", reqID, err)
+		return
+	}
+	l.entries++
+}
+
+// harHeaders converts headers to HAR name/value pairs, masking the values
+// of the headers listed in REDACT_HEADERS.
+func harHeaders(headers http.Header) []harNameValue {
+	pairs := []harNameValue{}
+	for name, values := range headers {
+		for _, v := range values {
+			if com.IsSliceContainsStr(REDACT_HEADERS, name) {
+				v = "***"
+			}
+			pairs = append(pairs, harNameValue{Name: name, Value: v})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Name < pairs[j].Name })
+	return pairs
+}
@@ -0,0 +1,283 @@
+package huaweicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+// The polling defaults shared by every asynchronous operation: wait a little
+// before the first poll, then back off from the minimum to the maximum
+// interval. They are variables so that the tests can shorten them.
+var (
+	asyncPollDelay       = 5 * time.Second
+	asyncPollMinInterval = 3 * time.Second
+	asyncPollMaxInterval = 10 * time.Second
+)
+
+// The statuses of an asynchronous job.
+const (
+	jobStatusInit    = "INIT"
+	jobStatusRunning = "RUNNING"
+	jobStatusSuccess = "SUCCESS"
+	jobStatusFail    = "FAIL"
+)
+
+// jobStatus is the status of an asynchronous job. Jobs made of several steps
+// report them as sub-jobs, either at the top level or under entities.
+type jobStatus struct {
+	JobID      string                 `json:"job_id"`
+	JobType    string                 `json:"job_type"`
+	Status     string                 `json:"status"`
+	ErrorCode  string                 `json:"error_code"`
+	FailReason string                 `json:"fail_reason"`
+	BeginTime  string                 `json:"begin_time"`
+	EndTime    string                 `json:"end_time"`
+	Entities   map[string]interface{} `json:"entities"`
+	SubJobs    []jobStatus            `json:"sub_jobs"`
+}
+
+// subJobs returns the sub-jobs of j wherever the service reports them.
+func (j *jobStatus) subJobs() []jobStatus {
+	if len(j.SubJobs) > 0 {
+		return j.SubJobs
+	}
+
+	raw, ok := j.Entities["sub_jobs"]
+	if !ok {
+		return nil
+	}
+
+	// Round-trip through JSON rather than converting the maps by hand.
+	var subJobs []jobStatus
+	b, err := json.Marshal(raw)
+	if err == nil {
+		err = json.Unmarshal(b, &subJobs)
+	}
+	if err != nil {
+		log.Printf("[WARN] Unable to parse the sub-jobs of job %s: %s", j.JobID, err)
+		return nil
+	}
+	return subJobs
+}
+
+// failure describes why j failed, including the reasons of its failed
+// sub-jobs, which are usually more telling than that of the job itself.
+func (j *jobStatus) failure() error {
+	reasons := []string{}
+	if r := jobFailReason(j); r != "" {
+		reasons = append(reasons, r)
+	}
+	for _, s := range j.subJobs() {
+		if s.Status != jobStatusFail {
+			continue
+		}
+		if r := jobFailReason(&s); r != "" {
+			reasons = append(reasons, fmt.Sprintf("sub-job %s (%s): %s", s.JobID, s.JobType, r))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("sub-job %s (%s) failed", s.JobID, s.JobType))
+		}
+	}
+
+	if len(reasons) == 0 {
+		return fmt.Errorf("job %s (%s) failed", j.JobID, j.JobType)
+	}
+	return fmt.Errorf("job %s (%s) failed: %s", j.JobID, j.JobType, strings.Join(reasons, "; "))
+}
+
+func jobFailReason(j *jobStatus) string {
+	switch {
+	case j.ErrorCode != "" && j.FailReason != "":
+		return fmt.Sprintf("%s: %s", j.ErrorCode, j.FailReason)
+	case j.FailReason != "":
+		return j.FailReason
+	default:
+		return j.ErrorCode
+	}
+}
+
+// jobQueryFunc fetches the status of the job found at uri.
+type jobQueryFunc func(uri string) (*jobStatus, error)
+
+// jobSubmitFunc starts an asynchronous job and returns its handle.
+type jobSubmitFunc func() (*golangsdk.JobResponse, error)
+
+// jobTracker submits and follows the asynchronous jobs of a service.
+type jobTracker struct {
+	// Service names the service in log and error messages.
+	Service string
+	Query   jobQueryFunc
+}
+
+// Run submits a job once and waits for it to finish. The submission is not
+// retried as the service may have accepted it despite an error, use
+// RunWithRetry for idempotent calls. Errors returned by submit are passed
+// through as is so callers can still check them, e.g. with
+// isResourceNotFound.
+func (t *jobTracker) Run(ctx context.Context, submit jobSubmitFunc) (*jobStatus, error) {
+	job, err := submit()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Submitted %s job %s", t.Service, job.JobID)
+	return t.Wait(ctx, job)
+}
+
+// RunWithRetry is Run for idempotent calls, such as updates and deletions,
+// the submission is retried while the service is busy.
+func (t *jobTracker) RunWithRetry(ctx context.Context, submit jobSubmitFunc) (*jobStatus, error) {
+	var job *golangsdk.JobResponse
+	err := retryContext(ctx, func() *resource.RetryError {
+		j, err := submit()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		job = j
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Submitted %s job %s", t.Service, job.JobID)
+	return t.Wait(ctx, job)
+}
+
+// Wait polls job until it succeeds, fails or ctx is done.
+func (t *jobTracker) Wait(ctx context.Context, job *golangsdk.JobResponse) (*jobStatus, error) {
+	log.Printf("[DEBUG] Waiting for %s job %s to become %s", t.Service, job.JobID, jobStatusSuccess)
+
+	var last *jobStatus
+	err := pollContext(ctx, func() (bool, error) {
+		s, err := t.Query(job.URI)
+		if err != nil {
+			return false, err
+		}
+		last = s
+
+		log.Printf("[DEBUG] %s job %s is %s", t.Service, job.JobID, s.Status)
+		switch s.Status {
+		case jobStatusSuccess:
+			return true, nil
+		case jobStatusFail:
+			return false, s.failure()
+		case jobStatusInit, jobStatusRunning:
+			return false, nil
+		default:
+			return false, fmt.Errorf("job %s has unexpected status %q", job.JobID, s.Status)
+		}
+	})
+	if err != nil {
+		if err == context.DeadlineExceeded && last != nil {
+			err = fmt.Errorf("timeout while waiting for job %s, last status %s", job.JobID, last.Status)
+		}
+		return nil, fmt.Errorf("Error waiting for %s job %s: %s", t.Service, job.JobID, err)
+	}
+
+	return last, nil
+}
+
+// waitForStatus polls refresh until it reports one of the target states.
+// It is the job-less counterpart of jobTracker.Wait for services which only
+// expose the status of the resource itself. An empty state is treated as
+// pending.
+func waitForStatus(ctx context.Context, name string, pending, target []string, refresh resource.StateRefreshFunc) (interface{}, error) {
+	log.Printf("[DEBUG] Waiting for %s to become %s", name, strings.Join(target, "/"))
+
+	var result interface{}
+	var state string
+	err := pollContext(ctx, func() (bool, error) {
+		r, s, err := refresh()
+		if err != nil {
+			return false, err
+		}
+		result, state = r, s
+
+		for _, t := range target {
+			if s == t {
+				return true, nil
+			}
+		}
+		if s == "" {
+			return false, nil
+		}
+		for _, p := range pending {
+			if s == p {
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("unexpected state %q, wanted %s", s, strings.Join(target, "/"))
+	})
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			err = fmt.Errorf("timeout while waiting, last state %q", state)
+		}
+		return nil, fmt.Errorf("Error waiting for %s to become %s: %s", name, strings.Join(target, "/"), err)
+	}
+
+	return result, nil
+}
+
+// pollContext calls f until it is done or fails, backing off between calls,
+// and gives up with ctx.Err() once ctx is done.
+func pollContext(ctx context.Context, f func() (bool, error)) error {
+	wait := asyncPollDelay
+	interval := asyncPollMinInterval
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		done, err := f()
+		if err != nil || done {
+			return err
+		}
+
+		wait = interval
+		if interval *= 2; interval > asyncPollMaxInterval {
+			interval = asyncPollMaxInterval
+		}
+	}
+}
+
+// retryContext is resource.Retry bounded by ctx rather than a timeout.
+func retryContext(ctx context.Context, f resource.RetryFunc) error {
+	var lastErr error
+	for {
+		rerr := f()
+		if rerr == nil {
+			return nil
+		}
+		if !rerr.Retryable {
+			return rerr.Err
+		}
+		lastErr = rerr.Err
+		log.Printf("[DEBUG] Retrying after error: %s", lastErr)
+
+		timer := time.NewTimer(asyncPollMinInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return lastErr
+		case <-timer.C:
+		}
+	}
+}
+
+// timeoutContext returns a context which expires after the timeout the user
+// configured for the given operation (schema.TimeoutCreate, ...).
+func timeoutContext(d *schema.ResourceData, key string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), d.Timeout(key))
+}
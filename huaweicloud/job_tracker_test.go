@@ -0,0 +1,139 @@
+package huaweicloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+func shortenAsyncPolling() func() {
+	delay, min, max := asyncPollDelay, asyncPollMinInterval, asyncPollMaxInterval
+	asyncPollDelay, asyncPollMinInterval, asyncPollMaxInterval = time.Millisecond, time.Millisecond, 5*time.Millisecond
+	return func() {
+		asyncPollDelay, asyncPollMinInterval, asyncPollMaxInterval = delay, min, max
+	}
+}
+
+func TestJobTracker_subJobFailure(t *testing.T) {
+	defer shortenAsyncPolling()()
+
+	statuses := []*jobStatus{
+		{JobID: "job-1", JobType: "createELB", Status: jobStatusRunning},
+		{
+			JobID:   "job-1",
+			JobType: "createELB",
+			Status:  jobStatusFail,
+			Entities: map[string]interface{}{
+				"sub_jobs": []interface{}{
+					map[string]interface{}{"job_id": "sub-1", "job_type": "createVIP", "status": jobStatusSuccess},
+					map[string]interface{}{"job_id": "sub-2", "job_type": "bindEIP", "status": jobStatusFail,
+						"error_code": "ELB.1102", "fail_reason": "EIP is in use"},
+				},
+			},
+		},
+	}
+
+	var polls int
+	tracker := &jobTracker{
+		Service: "elb",
+		Query: func(uri string) (*jobStatus, error) {
+			if uri != "/v1.0/jobs/job-1" {
+				t.Fatalf("unexpected job URI: %s", uri)
+			}
+			s := statuses[polls]
+			polls++
+			return s, nil
+		},
+	}
+
+	_, err := tracker.Run(context.Background(), func() (*golangsdk.JobResponse, error) {
+		return &golangsdk.JobResponse{JobID: "job-1", URI: "/v1.0/jobs/job-1"}, nil
+	})
+	if err == nil {
+		t.Fatal("expected the job to fail")
+	}
+	if !strings.Contains(err.Error(), "sub-job sub-2 (bindEIP): ELB.1102: EIP is in use") {
+		t.Fatalf("expected the failed sub-job in the error, got: %s", err)
+	}
+	if strings.Contains(err.Error(), "sub-1") {
+		t.Fatalf("succeeded sub-jobs should not be reported, got: %s", err)
+	}
+}
+
+func TestJobTracker_retrySubmit(t *testing.T) {
+	defer shortenAsyncPolling()()
+
+	var submits int
+	tracker := &jobTracker{
+		Service: "elb",
+		Query: func(uri string) (*jobStatus, error) {
+			return &jobStatus{JobID: "job-1", Status: jobStatusSuccess,
+				Entities: map[string]interface{}{"elb": map[string]interface{}{"id": "elb-1"}}}, nil
+		},
+	}
+
+	s, err := tracker.RunWithRetry(context.Background(), func() (*golangsdk.JobResponse, error) {
+		submits++
+		if submits == 1 {
+			return nil, golangsdk.ErrUnexpectedResponseCode{Actual: 409}
+		}
+		return &golangsdk.JobResponse{JobID: "job-1", URI: "/v1.0/jobs/job-1"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if submits != 2 {
+		t.Fatalf("expected the submission to be retried once, got %d submissions", submits)
+	}
+	if s.Entities["elb"].(map[string]interface{})["id"] != "elb-1" {
+		t.Fatalf("unexpected job entities: %#v", s.Entities)
+	}
+}
+
+func TestJobTracker_submitOnce(t *testing.T) {
+	defer shortenAsyncPolling()()
+
+	var submits int
+	tracker := &jobTracker{
+		Service: "elb",
+		Query: func(uri string) (*jobStatus, error) {
+			t.Fatal("no job should be polled")
+			return nil, nil
+		},
+	}
+
+	_, err := tracker.Run(context.Background(), func() (*golangsdk.JobResponse, error) {
+		submits++
+		return nil, golangsdk.ErrUnexpectedResponseCode{Actual: 500}
+	})
+	if err == nil {
+		t.Fatal("expected the submission to fail")
+	}
+	if submits != 1 {
+		t.Fatalf("expected a single submission, got %d submissions", submits)
+	}
+}
+
+func TestWaitForStatus_timeout(t *testing.T) {
+	defer shortenAsyncPolling()()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := waitForStatus(ctx, "EIP eip-1", nil, []string{"ACTIVE"}, func() (interface{}, string, error) {
+		return struct{}{}, "", nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "timeout while waiting") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+
+	_, err = waitForStatus(context.Background(), "EIP eip-1", nil, []string{"ACTIVE"}, func() (interface{}, string, error) {
+		return struct{}{}, "ERROR", nil
+	})
+	if err == nil || !strings.Contains(err.Error(), `unexpected state "ERROR"`) {
+		t.Fatalf("expected the ERROR state to fail the wait, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package huaweicloud
+
+import (
+	"github.com/huaweicloud/golangsdk"
+)
+
+// kmsKeyPairProtection hosts the private key of a key pair, encrypted with a
+// KMS key.
+type kmsKeyPairProtection struct {
+	PrivateKey string               `json:"private_key"`
+	Encryption kmsKeyPairEncryption `json:"encryption"`
+}
+
+type kmsKeyPairEncryption struct {
+	Type       string `json:"type"`
+	KmsKeyName string `json:"kms_key_name"`
+}
+
+// importKmsKeyPairPrivateKey stores the private key of an existing key pair,
+// encrypted with the KMS key named kmsKeyName.
+func importKmsKeyPairPrivateKey(c *golangsdk.ServiceClient, name, privateKey, kmsKeyName string) error {
+	b := map[string]interface{}{
+		"keypair": map[string]interface{}{
+			"name": name,
+			"key_protection": kmsKeyPairProtection{
+				PrivateKey: privateKey,
+				Encryption: kmsKeyPairEncryption{
+					Type:       "kms",
+					KmsKeyName: kmsKeyName,
+				},
+			},
+		},
+	}
+	_, err := c.Post(c.ServiceURL(c.ProjectID, "keypairs", "private-key", "import"), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
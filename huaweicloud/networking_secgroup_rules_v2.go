@@ -0,0 +1,317 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// secGroupRuleBatchSize is the number of rules created or deleted at once
+// when the rules of a security group are synchronized.
+const secGroupRuleBatchSize = 10
+
+// secGroupRule is a security group rule with the description which the
+// vendored rules package does not decode.
+type secGroupRule struct {
+	rules.SecGroupRule
+	Description string `json:"description"`
+}
+
+// secGroupRuleCreateOpts adds the description to rules.CreateOpts.
+type secGroupRuleCreateOpts struct {
+	rules.CreateOpts
+	Description string
+}
+
+func (opts secGroupRuleCreateOpts) ToSecGroupRuleCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOpts.ToSecGroupRuleCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Description != "" {
+		b["security_group_rule"].(map[string]interface{})["description"] = opts.Description
+	}
+	return b, nil
+}
+
+func createSecGroupRule(client *gophercloud.ServiceClient, opts secGroupRuleCreateOpts) (*secGroupRule, error) {
+	var s struct {
+		SecGroupRule *secGroupRule `json:"security_group_rule"`
+	}
+	err := rules.Create(client, opts).ExtractInto(&s)
+	return s.SecGroupRule, err
+}
+
+func getSecGroupRule(client *gophercloud.ServiceClient, id string) (*secGroupRule, error) {
+	var s struct {
+		SecGroupRule *secGroupRule `json:"security_group_rule"`
+	}
+	err := rules.Get(client, id).ExtractInto(&s)
+	return s.SecGroupRule, err
+}
+
+func listSecGroupRules(client *gophercloud.ServiceClient, opts rules.ListOpts) ([]secGroupRule, error) {
+	var all []secGroupRule
+	err := rules.List(client, opts).EachPage(func(page pagination.Page) (bool, error) {
+		var s struct {
+			SecGroupRules []secGroupRule `json:"security_group_rules"`
+		}
+		if err := page.(rules.SecGroupRulePage).ExtractInto(&s); err != nil {
+			return false, err
+		}
+		all = append(all, s.SecGroupRules...)
+		return true, nil
+	})
+	return all, err
+}
+
+// secGroupRuleSchema is the schema of an inline rule of a security group.
+func secGroupRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"direction": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"ingress", "egress"})
+				},
+			},
+			"ethertype": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"IPv4", "IPv6"})
+				},
+			},
+			"protocol": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+			"port_range_min": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"port_range_max": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"remote_ip_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+			"remote_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// secGroupRuleTraffic identifies the traffic a rule allows, the API rejects
+// a rule which allows the same traffic as another rule of the group.
+func secGroupRuleTraffic(m map[string]interface{}) string {
+	return fmt.Sprintf("%s-%s-%s-%d-%d-%s-%s",
+		m["direction"].(string),
+		m["ethertype"].(string),
+		strings.ToLower(m["protocol"].(string)),
+		m["port_range_min"].(int),
+		m["port_range_max"].(int),
+		strings.ToLower(m["remote_ip_prefix"].(string)),
+		m["remote_group_id"].(string))
+}
+
+// secGroupRuleKey identifies a rule of a group regardless of its ID.
+func secGroupRuleKey(m map[string]interface{}) string {
+	return secGroupRuleTraffic(m) + "-" + m["description"].(string)
+}
+
+func resourceSecGroupRuleHash(v interface{}) int {
+	return hashcode.String(secGroupRuleKey(v.(map[string]interface{})))
+}
+
+func flattenSecGroupRule(rule secGroupRule) map[string]interface{} {
+	return map[string]interface{}{
+		"id":               rule.ID,
+		"direction":        rule.Direction,
+		"ethertype":        rule.EtherType,
+		"protocol":         rule.Protocol,
+		"port_range_min":   rule.PortRangeMin,
+		"port_range_max":   rule.PortRangeMax,
+		"remote_ip_prefix": rule.RemoteIPPrefix,
+		"remote_group_id":  rule.RemoteGroupID,
+		"description":      rule.Description,
+	}
+}
+
+func flattenSecGroupRules(list []secGroupRule) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, rule := range list {
+		result = append(result, flattenSecGroupRule(rule))
+	}
+	return result
+}
+
+func expandSecGroupRuleCreateOpts(secGroupID string, m map[string]interface{}) (secGroupRuleCreateOpts, error) {
+	protocol := m["protocol"].(string)
+	portRangeMin := m["port_range_min"].(int)
+	portRangeMax := m["port_range_max"].(int)
+	if protocol == "" && (portRangeMin != 0 || portRangeMax != 0) {
+		return secGroupRuleCreateOpts{}, fmt.Errorf(
+			"A protocol must be specified when using port_range_min and port_range_max")
+	}
+
+	opts := secGroupRuleCreateOpts{
+		CreateOpts: rules.CreateOpts{
+			SecGroupID:     secGroupID,
+			Direction:      resourceNetworkingSecGroupRuleV2DetermineDirection(m["direction"].(string)),
+			EtherType:      resourceNetworkingSecGroupRuleV2DetermineEtherType(m["ethertype"].(string)),
+			PortRangeMin:   portRangeMin,
+			PortRangeMax:   portRangeMax,
+			RemoteGroupID:  m["remote_group_id"].(string),
+			RemoteIPPrefix: m["remote_ip_prefix"].(string),
+		},
+		Description: m["description"].(string),
+	}
+	if protocol != "" {
+		opts.Protocol = resourceNetworkingSecGroupRuleV2DetermineProtocol(strings.ToLower(protocol))
+	}
+	return opts, nil
+}
+
+// diffSecGroupRules returns the desired rules missing from the current ones
+// and the current rules which are not desired.
+func diffSecGroupRules(current []secGroupRule, desired []interface{}) (create []map[string]interface{}, remove []secGroupRule) {
+	currentKeys := make(map[string]bool)
+	for _, rule := range current {
+		currentKeys[secGroupRuleKey(flattenSecGroupRule(rule))] = true
+	}
+	desiredKeys := make(map[string]bool)
+	for _, v := range desired {
+		m := v.(map[string]interface{})
+		key := secGroupRuleKey(m)
+		desiredKeys[key] = true
+		if !currentKeys[key] {
+			create = append(create, m)
+		}
+	}
+	for _, rule := range current {
+		if !desiredKeys[secGroupRuleKey(flattenSecGroupRule(rule))] {
+			remove = append(remove, rule)
+		}
+	}
+	return
+}
+
+// syncSecGroupRules makes the rules of the security group exactly the
+// desired ones. The missing rules are added before the others are removed,
+// except when the same traffic is allowed with another description: the
+// API rejects such duplicates, so their old rule is removed first.
+func syncSecGroupRules(client *gophercloud.ServiceClient, secGroupID string, desired []interface{}) error {
+	current, err := listSecGroupRules(client, rules.ListOpts{SecGroupID: secGroupID})
+	if err != nil {
+		return fmt.Errorf("Error listing the rules of security group %s: %s", secGroupID, err)
+	}
+	create, remove := diffSecGroupRules(current, desired)
+
+	created := make(map[string]bool)
+	for _, m := range create {
+		created[secGroupRuleTraffic(m)] = true
+	}
+	var first, last []secGroupRule
+	for _, rule := range remove {
+		if created[secGroupRuleTraffic(flattenSecGroupRule(rule))] {
+			first = append(first, rule)
+		} else {
+			last = append(last, rule)
+		}
+	}
+
+	if err := deleteSecGroupRules(client, first); err != nil {
+		return err
+	}
+	if err := createSecGroupRules(client, secGroupID, create); err != nil {
+		return err
+	}
+	return deleteSecGroupRules(client, last)
+}
+
+func createSecGroupRules(client *gophercloud.ServiceClient, secGroupID string, list []map[string]interface{}) error {
+	opts := make([]secGroupRuleCreateOpts, 0, len(list))
+	for _, m := range list {
+		o, err := expandSecGroupRuleCreateOpts(secGroupID, m)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, o)
+	}
+
+	return inSecGroupRuleBatches(len(opts), func(i int) error {
+		log.Printf("[DEBUG] Create HuaweiCloud Neutron security group rule: %#v", opts[i])
+		if _, err := createSecGroupRule(client, opts[i]); err != nil {
+			return fmt.Errorf("Error creating rule of security group %s: %s", secGroupID, err)
+		}
+		return nil
+	})
+}
+
+func deleteSecGroupRules(client *gophercloud.ServiceClient, list []secGroupRule) error {
+	return inSecGroupRuleBatches(len(list), func(i int) error {
+		log.Printf("[DEBUG] Destroy security group rule: %s", list[i].ID)
+		err := rules.Delete(client, list[i].ID).ExtractErr()
+		if _, ok := err.(gophercloud.ErrDefault404); ok || err == nil {
+			return nil
+		}
+		return fmt.Errorf("Error deleting security group rule %s: %s", list[i].ID, err)
+	})
+}
+
+// inSecGroupRuleBatches calls f for 0..n-1, secGroupRuleBatchSize calls at a
+// time, and returns the first error of a batch.
+func inSecGroupRuleBatches(n int, f func(i int) error) error {
+	for start := 0; start < n; start += secGroupRuleBatchSize {
+		end := start + secGroupRuleBatchSize
+		if end > n {
+			end = n
+		}
+
+		errs := make([]error, end-start)
+		var wg sync.WaitGroup
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i-start] = f(i)
+			}(i)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package huaweicloud
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	obsHeaderPrefix        = "x-obs-"
+	obsSecurityTokenHeader = "X-Obs-Security-Token"
+)
+
+// obsSubResources are the query parameters which are part of the resource a
+// request is signed for.
+var obsSubResources = map[string]bool{
+	"acl":                   true,
+	"append":                true,
+	"cors":                  true,
+	"delete":                true,
+	"encryption":            true,
+	"lifecycle":             true,
+	"location":              true,
+	"logging":               true,
+	"notification":          true,
+	"partNumber":            true,
+	"policy":                true,
+	"position":              true,
+	"quota":                 true,
+	"replication":           true,
+	"storageClass":          true,
+	"storageinfo":           true,
+	"tagging":               true,
+	"uploadId":              true,
+	"uploads":               true,
+	"versionId":             true,
+	"versioning":            true,
+	"versions":              true,
+	"website":               true,
+	"response-content-type": true,
+}
+
+// obsClient calls the native OBS API. Its requests are signed with the
+// OBS signature (Authorization: OBS AK:signature) using the credentials of
+// the provider, OBS does not accept the IAM token.
+type obsClient struct {
+	Endpoint    *url.URL
+	Region      string
+	HTTPClient  *http.Client
+	Credentials *credentialChain
+}
+
+func newOBSClient(endpoint, region string, httpClient *http.Client, credentials *credentialChain) (*obsClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing OBS endpoint %q: %s", endpoint, err)
+	}
+	u.Path = "/"
+
+	return &obsClient{
+		Endpoint:    u,
+		Region:      region,
+		HTTPClient:  httpClient,
+		Credentials: credentials,
+	}, nil
+}
+
+// obsRequest is a request to a bucket, or to an object if Key is set.
+type obsRequest struct {
+	Method string
+	Bucket string
+	Key    string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+type obsResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// obsError is the error document returned by OBS.
+type obsError struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+	RequestID  string `xml:"RequestId"`
+	HostID     string `xml:"HostId"`
+}
+
+func (e *obsError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("OBS request failed with status %d (request %s)", e.StatusCode, e.RequestID)
+	}
+	return fmt.Sprintf("%s: %s (status %d, request %s)", e.Code, e.Message, e.StatusCode, e.RequestID)
+}
+
+// isOBSNotFound reports whether err is an OBS 404, optionally restricted to
+// the given error codes.
+func isOBSNotFound(err error, codes ...string) bool {
+	e, ok := err.(*obsError)
+	if !ok || e.StatusCode != http.StatusNotFound {
+		return false
+	}
+	if len(codes) == 0 {
+		return true
+	}
+	for _, c := range codes {
+		if e.Code == c {
+			return true
+		}
+	}
+	return false
+}
+
+// isOBSErrorCode reports whether err is an OBS error with the given code.
+func isOBSErrorCode(err error, code string) bool {
+	e, ok := err.(*obsError)
+	return ok && e.Code == code
+}
+
+// bucketHost returns the virtual-hosted style host of bucket.
+func (c *obsClient) bucketHost(bucket string) string {
+	if bucket == "" {
+		return c.Endpoint.Host
+	}
+	return bucket + "." + c.Endpoint.Host
+}
+
+// BucketDomainName returns the domain name of bucket.
+func (c *obsClient) BucketDomainName(bucket string) string {
+	return c.bucketHost(bucket)
+}
+
+// Do sends r and returns the response, or an *obsError for error statuses.
+func (c *obsClient) Do(r *obsRequest) (*obsResponse, error) {
+	u := &url.URL{
+		Scheme:   c.Endpoint.Scheme,
+		Host:     c.bucketHost(r.Bucket),
+		Path:     "/" + r.Key,
+		RawQuery: obsEncodeQuery(r.Query),
+	}
+
+	request, err := http.NewRequest(r.Method, u.String(), bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.Header {
+		for _, v := range values {
+			request.Header.Add(name, v)
+		}
+	}
+	if len(r.Body) > 0 {
+		if request.Header.Get("Content-Type") == "" {
+			request.Header.Set("Content-Type", "application/xml")
+		}
+		if request.Header.Get("Content-MD5") == "" {
+			sum := md5.Sum(r.Body)
+			request.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+		}
+	}
+
+	v, err := c.Credentials.Get()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting credentials to sign OBS request: %s", err)
+	}
+	signOBSRequest(request, r.Bucket, r.Query, v, time.Now())
+
+	log.Printf("[DEBUG] OBS request: %s %s", r.Method, u.String())
+	resp, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		e := &obsError{StatusCode: resp.StatusCode}
+		if len(body) > 0 {
+			xml.Unmarshal(body, e)
+		}
+		if e.RequestID == "" {
+			e.RequestID = resp.Header.Get("X-Obs-Request-Id")
+		}
+		return nil, e
+	}
+
+	return &obsResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// DoXML sends r with in marshalled as its body, if set, and unmarshals the
+// response into out, if set.
+func (c *obsClient) DoXML(r *obsRequest, in, out interface{}) (*obsResponse, error) {
+	if in != nil {
+		b, err := xml.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = b
+	}
+
+	resp, err := c.Do(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if out != nil {
+		if err := xml.Unmarshal(resp.Body, out); err != nil {
+			return nil, fmt.Errorf("Error decoding OBS response: %s", err)
+		}
+	}
+	return resp, nil
+}
+
+// signOBSRequest sets the Date and Authorization headers of request.
+func signOBSRequest(request *http.Request, bucket string, query url.Values, v credentialValue, now time.Time) {
+	request.Header.Set("Date", now.UTC().Format(http.TimeFormat))
+	if v.SecurityToken != "" {
+		request.Header.Set(obsSecurityTokenHeader, v.SecurityToken)
+	}
+
+	mac := hmac.New(sha1.New, []byte(v.SecretKey))
+	mac.Write([]byte(obsStringToSign(request, bucket, query)))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	request.Header.Set("Authorization", fmt.Sprintf("OBS %s:%s", v.AccessKey, signature))
+}
+
+// obsStringToSign returns the string to sign for request. The object key is
+// signed as it is sent, escaped.
+func obsStringToSign(request *http.Request, bucket string, query url.Values) string {
+	var b bytes.Buffer
+	b.WriteString(request.Method + "\n")
+	b.WriteString(request.Header.Get("Content-MD5") + "\n")
+	b.WriteString(request.Header.Get("Content-Type") + "\n")
+	b.WriteString(request.Header.Get("Date") + "\n")
+
+	var names []string
+	for name := range request.Header {
+		if strings.HasPrefix(strings.ToLower(name), obsHeaderPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	for _, name := range names {
+		b.WriteString(strings.ToLower(name) + ":" + strings.Join(request.Header[name], ",") + "\n")
+	}
+
+	b.WriteString("/")
+	if bucket != "" {
+		b.WriteString(bucket + "/")
+	}
+	b.WriteString(strings.TrimPrefix(request.URL.EscapedPath(), "/"))
+
+	var subResources []string
+	for k := range query {
+		if obsSubResources[k] {
+			subResources = append(subResources, k)
+		}
+	}
+	sort.Strings(subResources)
+	for i, k := range subResources {
+		if i == 0 {
+			b.WriteString("?")
+		} else {
+			b.WriteString("&")
+		}
+		b.WriteString(k)
+		if v := query.Get(k); v != "" {
+			b.WriteString("=" + v)
+		}
+	}
+
+	return b.String()
+}
+
+// obsEncodeQuery encodes query, leaving the sub-resources without a value
+// as bare names (?quota rather than ?quota=).
+func obsEncodeQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			if v == "" {
+				pairs = append(pairs, url.QueryEscape(k))
+			} else {
+				pairs = append(pairs, url.QueryEscape(k)+"="+url.QueryEscape(v))
+			}
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// obsSubResource returns the query of a request to a sub-resource.
+func obsSubResource(name string) url.Values {
+	return url.Values{name: []string{""}}
+}
@@ -0,0 +1,105 @@
+package huaweicloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestObsStringToSign(t *testing.T) {
+	request, _ := http.NewRequest("PUT", "https://bucket.obs.cn-north-1.myhuaweicloud.com/?quota", nil)
+	request.Header.Set("Content-MD5", "md5")
+	request.Header.Set("Content-Type", "application/xml")
+	request.Header.Set("Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+	request.Header.Set("X-Obs-Storage-Class", "WARM")
+	request.Header.Set("X-Obs-Acl", "private")
+
+	query := url.Values{"quota": []string{""}, "max-keys": []string{"10"}}
+	expected := "PUT\nmd5\napplication/xml\nMon, 02 Jan 2006 15:04:05 GMT\n" +
+		"x-obs-acl:private\nx-obs-storage-class:WARM\n/bucket/?quota"
+	if s := obsStringToSign(request, "bucket", query); s != expected {
+		t.Fatalf("unexpected string to sign:\n%q\nexpected:\n%q", s, expected)
+	}
+}
+
+func TestObsClient_signEscapedKey(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+	}))
+	defer server.Close()
+
+	v := credentialValue{AccessKey: "ak", SecretKey: "sk"}
+	chain := &credentialChain{Providers: []credentialProvider{&staticCredentialProvider{Value: v}}}
+	client, err := newOBSClient(server.URL, "cn-north-1", http.DefaultClient, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(&obsRequest{Method: "GET", Key: "dir/a b+c%d-é.txt"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The server checks the signature against the path it receives.
+	r := requests[0]
+	if r.URL.EscapedPath() != "/dir/a%20b+c%25d-%C3%A9.txt" {
+		t.Fatalf("unexpected path: %s", r.URL.EscapedPath())
+	}
+	date, _ := http.ParseTime(r.Header.Get("Date"))
+	expected, _ := http.NewRequest("GET", "http://host"+r.URL.EscapedPath(), nil)
+	signOBSRequest(expected, "", nil, v, date)
+	if !strings.HasSuffix(obsStringToSign(expected, "", nil), "\n/dir/a%20b+c%25d-%C3%A9.txt") {
+		t.Fatalf("unexpected string to sign: %q", obsStringToSign(expected, "", nil))
+	}
+	if r.Header.Get("Authorization") != expected.Header.Get("Authorization") {
+		t.Fatalf("expected Authorization %s, got %s", expected.Header.Get("Authorization"), r.Header.Get("Authorization"))
+	}
+}
+
+func TestObsClient_Do(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if r.URL.RawQuery == "encryption" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchEncryptionConfiguration</Code>` +
+				`<Message>The server side encryption configuration was not found</Message><RequestId>req-1</RequestId></Error>`))
+			return
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Quota><StorageQuota>1024</StorageQuota></Quota>`))
+	}))
+	defer server.Close()
+
+	chain := &credentialChain{Providers: []credentialProvider{
+		&staticCredentialProvider{Value: credentialValue{AccessKey: "ak", SecretKey: "sk", SecurityToken: "token"}},
+	}}
+	client, err := newOBSClient(server.URL, "cn-north-1", http.DefaultClient, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The test server has no wildcard DNS, so leave the bucket out of the host.
+	var quota obsBucketQuota
+	if _, err := client.DoXML(&obsRequest{Method: "GET", Query: obsSubResource("quota")}, nil, &quota); err != nil {
+		t.Fatal(err)
+	}
+	if quota.StorageQuota != 1024 {
+		t.Fatalf("unexpected quota: %d", quota.StorageQuota)
+	}
+
+	r := requests[0]
+	if r.URL.RawQuery != "quota" {
+		t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "OBS ak:") || r.Header.Get("X-Obs-Security-Token") != "token" {
+		t.Fatalf("request was not signed: %v", r.Header)
+	}
+
+	_, err = client.Do(&obsRequest{Method: "GET", Query: obsSubResource("encryption")})
+	if !isOBSNotFound(err, "NoSuchEncryptionConfiguration") {
+		t.Fatalf("expected a NoSuchEncryptionConfiguration error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "req-1") {
+		t.Fatalf("expected the request ID in the error, got %s", err)
+	}
+}
@@ -1,6 +1,8 @@
 package huaweicloud
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -14,23 +16,57 @@ func Provider() terraform.ResourceProvider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"access_key": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_ACCESS_KEY", ""),
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_ACCESS_KEY",
+					"OS_ACCESS_KEY",
+				}, ""),
 				Description: descriptions["access_key"],
 			},
 
 			"secret_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_SECRET_KEY",
+					"OS_SECRET_KEY",
+				}, ""),
+				Description: descriptions["secret_key"],
+			},
+
+			"security_token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_SECURITY_TOKEN",
+					"OS_SECURITY_TOKEN",
+				}, ""),
+				Description: descriptions["security_token"],
+			},
+
+			"shared_credentials_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_SECRET_KEY", ""),
-				Description: descriptions["secret_key"],
+				DefaultFunc: schema.EnvDefaultFunc("HW_SHARED_CREDENTIALS_FILE", ""),
+				Description: descriptions["shared_credentials_file"],
 			},
 
-			"auth_url": &schema.Schema{
+			"profile": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_AUTH_URL", ""),
+				DefaultFunc: schema.EnvDefaultFunc("HW_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+
+			"auth_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_AUTH_URL",
+					"OS_AUTH_URL",
+				}, ""),
 				Description: descriptions["auth_url"],
 			},
 
@@ -38,55 +74,78 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: descriptions["region"],
-				DefaultFunc: schema.EnvDefaultFunc("OS_REGION_NAME", ""),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_REGION_NAME",
+					"OS_REGION_NAME",
+				}, ""),
 			},
 
 			"user_name": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_USERNAME", ""),
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_USER_NAME",
+					"OS_USERNAME",
+				}, ""),
 				Description: descriptions["user_name"],
 			},
 
 			"user_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_USER_ID", ""),
-				Description: descriptions["user_name"],
-			},
-
-			"tenant_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
-					"OS_TENANT_ID",
-					"OS_PROJECT_ID",
+					"HW_USER_ID",
+					"OS_USER_ID",
 				}, ""),
+				Description: descriptions["user_name"],
+			},
+
+			"tenant_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(projectEnvVars["tenant_id"], ""),
 				Description: descriptions["tenant_id"],
 			},
 
+			"project_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(projectEnvVars["project_id"], ""),
+				Description: descriptions["project_id"],
+			},
+
 			"tenant_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
-					"OS_TENANT_NAME",
-					"OS_PROJECT_NAME",
-				}, ""),
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(projectEnvVars["tenant_name"], ""),
 				Description: descriptions["tenant_name"],
 			},
 
-			"password": &schema.Schema{
+			"project_name": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_PASSWORD", ""),
+				DefaultFunc: schema.MultiEnvDefaultFunc(projectEnvVars["project_name"], ""),
+				Description: descriptions["project_name"],
+			},
+
+			"password": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_PASSWORD",
+					"OS_PASSWORD",
+				}, ""),
 				Description: descriptions["password"],
 			},
 
 			"token": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_AUTH_TOKEN", ""),
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_AUTH_TOKEN",
+					"OS_AUTH_TOKEN",
+				}, ""),
 				Description: descriptions["token"],
 			},
 
@@ -94,6 +153,7 @@ func Provider() terraform.ResourceProvider {
 				Type:     schema.TypeString,
 				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_DOMAIN_ID",
 					"OS_USER_DOMAIN_ID",
 					"OS_PROJECT_DOMAIN_ID",
 					"OS_DOMAIN_ID",
@@ -105,6 +165,7 @@ func Provider() terraform.ResourceProvider {
 				Type:     schema.TypeString,
 				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"HW_DOMAIN_NAME",
 					"OS_USER_DOMAIN_NAME",
 					"OS_PROJECT_DOMAIN_NAME",
 					"OS_DOMAIN_NAME",
@@ -190,23 +251,31 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"huaweicloud_networking_network_v2":     dataSourceNetworkingNetworkV2(),
-			"huaweicloud_networking_subnet_v2":      dataSourceNetworkingSubnetV2(),
-			"huaweicloud_networking_secgroup_v2":    dataSourceNetworkingSecGroupV2(),
-			"huaweicloud_s3_bucket_object":          dataSourceS3BucketObject(),
-			"huaweicloud_kms_key_v1":                dataSourceKmsKeyV1(),
-			"huaweicloud_kms_data_key_v1":           dataSourceKmsDataKeyV1(),
-			"huaweicloud_rds_flavors_v1":            dataSourceRdsFlavorV1(),
-			"huaweicloud_sfs_file_system_v2":        dataSourceSFSFileSystemV2(),
-			"huaweicloud_rts_stack_v1":              dataSourceRTSStackV1(),
-			"huaweicloud_rts_stack_resource_v1":     dataSourceRTSStackResourcesV1(),
-			"huaweicloud_iam_role_v3":               dataSourceIAMRoleV3(),
-			"huaweicloud_vpc_v1":                    dataSourceVirtualPrivateCloudVpcV1(),
-			"huaweicloud_vpc_peering_connection_v2": dataSourceVpcPeeringConnectionV2(),
-			"huaweicloud_vpc_route_v2":              dataSourceVPCRouteV2(),
-			"huaweicloud_vpc_route_ids_v2":          dataSourceVPCRouteIdsV2(),
-			"huaweicloud_vpc_subnet_v1":             dataSourceVpcSubnetV1(),
-			"huaweicloud_vpc_subnet_ids_v1":         dataSourceVpcSubnetIdsV1(),
+			"huaweicloud_networking_network_v2":        dataSourceNetworkingNetworkV2(),
+			"huaweicloud_networking_subnet_v2":         dataSourceNetworkingSubnetV2(),
+			"huaweicloud_networking_secgroup_v2":       dataSourceNetworkingSecGroupV2(),
+			"huaweicloud_networking_secgroup_rules_v2": dataSourceNetworkingSecGroupRulesV2(),
+			"huaweicloud_networking_port_v2":           dataSourceNetworkingPortV2(),
+			"huaweicloud_s3_bucket_object":             dataSourceS3BucketObject(),
+			"huaweicloud_s3_bucket_objects":            dataSourceS3BucketObjects(),
+			"huaweicloud_kms_key_v1":                   dataSourceKmsKeyV1(),
+			"huaweicloud_kms_data_key_v1":              dataSourceKmsDataKeyV1(),
+			"huaweicloud_rds_flavors_v1":               dataSourceRdsFlavorV1(),
+			"huaweicloud_sfs_file_system_v2":           dataSourceSFSFileSystemV2(),
+			"huaweicloud_smn_subscriptions_v2":         dataSourceSMNSubscriptionsV2(),
+			"huaweicloud_rts_stack_v1":                 dataSourceRTSStackV1(),
+			"huaweicloud_rts_stack_resource_v1":        dataSourceRTSStackResourcesV1(),
+			"huaweicloud_iam_role_v3":                  dataSourceIAMRoleV3(),
+			"huaweicloud_vpc_v1":                       dataSourceVirtualPrivateCloudVpcV1(),
+			"huaweicloud_vpc_eip_v1":                   dataSourceVpcEIPV1(),
+			"huaweicloud_vpc_peering_connection_v2":    dataSourceVpcPeeringConnectionV2(),
+			"huaweicloud_vpc_route_v2":                 dataSourceVPCRouteV2(),
+			"huaweicloud_vpc_route_ids_v2":             dataSourceVPCRouteIdsV2(),
+			"huaweicloud_vpc_subnet_v1":                dataSourceVpcSubnetV1(),
+			"huaweicloud_vpc_subnet_ids_v1":            dataSourceVpcSubnetIdsV1(),
+			"huaweicloud_regions":                      dataSourceRegions(),
+			"huaweicloud_identity_project_v3":          dataSourceIdentityProjectV3(),
+			"huaweicloud_identity_endpoints_v3":        dataSourceIdentityEndpointsV3(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -218,11 +287,13 @@ func Provider() terraform.ResourceProvider {
 			"huaweicloud_compute_floatingip_v2":              resourceComputeFloatingIPV2(),
 			"huaweicloud_compute_floatingip_associate_v2":    resourceComputeFloatingIPAssociateV2(),
 			"huaweicloud_compute_volume_attach_v2":           resourceComputeVolumeAttachV2(),
+			"huaweicloud_compute_interface_attach_v2":        resourceComputeInterfaceAttachV2(),
 			"huaweicloud_dns_recordset_v2":                   resourceDNSRecordSetV2(),
 			"huaweicloud_dns_zone_v2":                        resourceDNSZoneV2(),
 			"huaweicloud_fw_firewall_group_v2":               resourceFWFirewallGroupV2(),
 			"huaweicloud_fw_policy_v2":                       resourceFWPolicyV2(),
 			"huaweicloud_fw_rule_v2":                         resourceFWRuleV2(),
+			"huaweicloud_network_acl":                        resourceNetworkACL(),
 			"huaweicloud_kms_key_v1":                         resourceKmsKeyV1(),
 			"huaweicloud_elb_loadbalancer":                   resourceELBLoadBalancer(),
 			"huaweicloud_elb_listener":                       resourceELBListener(),
@@ -237,21 +308,28 @@ func Provider() terraform.ResourceProvider {
 			"huaweicloud_networking_subnet_v2":               resourceNetworkingSubnetV2(),
 			"huaweicloud_networking_floatingip_v2":           resourceNetworkingFloatingIPV2(),
 			"huaweicloud_networking_port_v2":                 resourceNetworkingPortV2(),
+			"huaweicloud_networking_vip_v2":                  resourceNetworkingVIPV2(),
+			"huaweicloud_networking_vip_associate_v2":        resourceNetworkingVIPAssociateV2(),
 			"huaweicloud_networking_router_v2":               resourceNetworkingRouterV2(),
 			"huaweicloud_networking_router_interface_v2":     resourceNetworkingRouterInterfaceV2(),
 			"huaweicloud_networking_router_route_v2":         resourceNetworkingRouterRouteV2(),
 			"huaweicloud_networking_secgroup_v2":             resourceNetworkingSecGroupV2(),
 			"huaweicloud_networking_secgroup_rule_v2":        resourceNetworkingSecGroupRuleV2(),
+			"huaweicloud_obs_bucket":                         resourceObsBucket(),
 			"huaweicloud_s3_bucket":                          resourceS3Bucket(),
 			"huaweicloud_s3_bucket_policy":                   resourceS3BucketPolicy(),
+			"huaweicloud_s3_bucket_notification":             resourceS3BucketNotification(),
 			"huaweicloud_s3_bucket_object":                   resourceS3BucketObject(),
+			"huaweicloud_s3_bucket_objects_sync":             resourceS3BucketObjectsSync(),
 			"huaweicloud_smn_topic_v2":                       resourceTopic(),
 			"huaweicloud_smn_subscription_v2":                resourceSubscription(),
+			"huaweicloud_smn_message_template_v2":            resourceSMNMessageTemplateV2(),
 			"huaweicloud_rds_instance_v1":                    resourceRdsInstance(),
 			"huaweicloud_nat_gateway_v2":                     resourceNatGatewayV2(),
 			"huaweicloud_nat_snat_rule_v2":                   resourceNatSnatRuleV2(),
 			"huaweicloud_vpc_eip_v1":                         resourceVpcEIPV1(),
 			"huaweicloud_sfs_file_system_v2":                 resourceSFSFileSystemV2(),
+			"huaweicloud_sfs_access_rule_v2":                 resourceSFSAccessRuleV2(),
 			"huaweicloud_rts_stack_v1":                       resourceRTSStackV1(),
 			"huaweicloud_iam_agency_v3":                      resourceIAMAgencyV3(),
 			"huaweicloud_vpc_v1":                             resourceVirtualPrivateCloudV1(),
@@ -259,6 +337,13 @@ func Provider() terraform.ResourceProvider {
 			"huaweicloud_vpc_peering_connection_accepter_v2": resourceVpcPeeringConnectionAccepterV2(),
 			"huaweicloud_vpc_route_v2":                       resourceVPCRouteV2(),
 			"huaweicloud_vpc_subnet_v1":                      resourceVpcSubnetV1(),
+			"huaweicloud_vpc_flow_log_v1":                    resourceVpcFlowLogV1(),
+			"huaweicloud_vpc_route_table":                    resourceVpcRouteTable(),
+			"huaweicloud_vpnaas_endpoint_group_v2":           resourceVpnEndpointGroupV2(),
+			"huaweicloud_vpnaas_ike_policy_v2":               resourceVpnIKEPolicyV2(),
+			"huaweicloud_vpnaas_ipsec_policy_v2":             resourceVpnIPSecPolicyV2(),
+			"huaweicloud_vpnaas_service_v2":                  resourceVpnServiceV2(),
+			"huaweicloud_vpnaas_site_connection_v2":          resourceVpnSiteConnectionV2(),
 		},
 
 		ConfigureFunc: configureProvider,
@@ -269,6 +354,17 @@ var descriptions map[string]string
 
 func init() {
 	descriptions = map[string]string{
+		"access_key": "The access key of the HuaweiCloud to use.",
+
+		"secret_key": "The secret key of the HuaweiCloud to use.",
+
+		"security_token": "The security token to use with a temporary access key.",
+
+		"shared_credentials_file": "The path to the shared credentials file. If not set\n" +
+			"this defaults to ~/.huaweicloud/credentials.",
+
+		"profile": "The profile of the shared credentials file to use.",
+
 		"auth_url": "The Identity authentication URL.",
 
 		"region": "The HuaweiCloud region to connect to.",
@@ -278,10 +374,14 @@ func init() {
 		"user_id": "User ID to login with.",
 
 		"tenant_id": "The ID of the Tenant (Identity v2) or Project (Identity v3)\n" +
-			"to login with.",
+			"to login with. Deprecated, use project_id instead.",
 
 		"tenant_name": "The name of the Tenant (Identity v2) or Project (Identity v3)\n" +
-			"to login with.",
+			"to login with. Deprecated, use project_name instead.",
+
+		"project_id": "The ID of the Project to login with.",
+
+		"project_name": "The name of the Project to login with.",
 
 		"password": "Password to login with.",
 
@@ -321,6 +421,7 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
 		AccessKey:        d.Get("access_key").(string),
 		SecretKey:        d.Get("secret_key").(string),
+		SecurityToken:    d.Get("security_token").(string),
 		CACertFile:       d.Get("cacert_file").(string),
 		ClientCertFile:   d.Get("cert").(string),
 		ClientKeyFile:    d.Get("key").(string),
@@ -334,14 +435,25 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		Region:           d.Get("region").(string),
 		Swauth:           d.Get("swauth").(bool),
 		Token:            d.Get("token").(string),
-		TenantID:         d.Get("tenant_id").(string),
-		TenantName:       d.Get("tenant_name").(string),
 		Username:         d.Get("user_name").(string),
 		UserID:           d.Get("user_id").(string),
 		useOctavia:       d.Get("use_octavia").(bool),
 		AgencyName:       d.Get("agency_name").(string),
 		AgencyDomainName: d.Get("agency_domain_name").(string),
 		DelegatedProject: d.Get("delegated_project").(string),
+
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
+		Profile:               d.Get("profile").(string),
+	}
+
+	var err error
+	config.TenantID, err = projectAlias(d, "project_id", "tenant_id")
+	if err != nil {
+		return nil, err
+	}
+	config.TenantName, err = projectAlias(d, "project_name", "tenant_name")
+	if err != nil {
+		return nil, err
 	}
 
 	if err := config.LoadAndValidate(); err != nil {
@@ -350,3 +462,43 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 
 	return &config, nil
 }
+
+// projectEnvVars are the environment variables the project arguments and
+// their Identity v2 aliases default to.
+var projectEnvVars = map[string][]string{
+	"tenant_id":    {"OS_TENANT_ID"},
+	"project_id":   {"HW_PROJECT_ID", "OS_PROJECT_ID"},
+	"tenant_name":  {"OS_TENANT_NAME"},
+	"project_name": {"HW_PROJECT_NAME", "OS_PROJECT_NAME"},
+}
+
+// projectAlias returns the value of the project argument name, or of its
+// Identity v2 alias. A value set in the configuration wins over one taken
+// from the environment, it fails if both are configured to different values.
+func projectAlias(d *schema.ResourceData, name, alias string) (string, error) {
+	v := d.Get(name).(string)
+	a := d.Get(alias).(string)
+	if v == "" {
+		return a, nil
+	}
+	if a == "" || a == v {
+		return v, nil
+	}
+
+	vFromEnv := projectFromEnv(name, v)
+	aFromEnv := projectFromEnv(alias, a)
+	switch {
+	case vFromEnv && !aFromEnv:
+		return a, nil
+	case aFromEnv:
+		return v, nil
+	}
+	return "", fmt.Errorf("%s (%q) and %s (%q) are aliases and must not be set to different values", name, v, alias, a)
+}
+
+// projectFromEnv reports whether the value of the project argument name is
+// the one of its environment variables, i.e. it is not configured.
+func projectFromEnv(name, value string) bool {
+	env, _ := schema.MultiEnvDefaultFunc(projectEnvVars[name], "")()
+	return env == value
+}
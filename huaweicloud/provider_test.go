@@ -21,9 +21,13 @@ var (
 	OS_FLAVOR_NAME            = os.Getenv("OS_FLAVOR_NAME")
 	OS_IMAGE_ID               = os.Getenv("OS_IMAGE_ID")
 	OS_IMAGE_NAME             = os.Getenv("OS_IMAGE_NAME")
+	OS_LTS_GROUP_ID           = os.Getenv("OS_LTS_GROUP_ID")
+	OS_LTS_TOPIC_ID           = os.Getenv("OS_LTS_TOPIC_ID")
 	OS_NETWORK_ID             = os.Getenv("OS_NETWORK_ID")
 	OS_POOL_NAME              = os.Getenv("OS_POOL_NAME")
+	OS_REBUILD_IMAGE_ID       = os.Getenv("OS_REBUILD_IMAGE_ID")
 	OS_REGION_NAME            = os.Getenv("OS_REGION_NAME")
+	OS_REPLICATION_REGION     = os.Getenv("OS_REPLICATION_REGION")
 	OS_ACCESS_KEY             = os.Getenv("OS_ACCESS_KEY")
 	OS_SECRET_KEY             = os.Getenv("OS_SECRET_KEY")
 	OS_VPC_ID                 = os.Getenv("OS_VPC_ID")
@@ -130,6 +134,30 @@ func testAccPreCheckELB(t *testing.T) {
 	}
 }
 
+func testAccPreCheckFlowLog(t *testing.T) {
+	testAccPreCheckRequiredEnvVars(t)
+
+	if OS_LTS_GROUP_ID == "" || OS_LTS_TOPIC_ID == "" {
+		t.Skip("OS_LTS_GROUP_ID and OS_LTS_TOPIC_ID must be set for flow log tests")
+	}
+}
+
+func testAccPreCheckRebuild(t *testing.T) {
+	testAccPreCheckRequiredEnvVars(t)
+
+	if OS_REBUILD_IMAGE_ID == "" {
+		t.Skip("OS_REBUILD_IMAGE_ID must be set for rebuild tests")
+	}
+}
+
+func testAccPreCheckS3Replication(t *testing.T) {
+	testAccPreCheckRequiredEnvVars(t)
+
+	if OS_REPLICATION_REGION == "" {
+		t.Skip("OS_REPLICATION_REGION must be set for replication tests")
+	}
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -140,6 +168,43 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProjectAlias(t *testing.T) {
+	for _, env := range []string{"OS_TENANT_NAME", "HW_PROJECT_NAME", "OS_PROJECT_NAME"} {
+		defer os.Setenv(env, os.Getenv(env))
+		os.Unsetenv(env)
+	}
+
+	cases := []struct {
+		raw      map[string]interface{}
+		env      string
+		expected string
+		err      bool
+	}{
+		{raw: map[string]interface{}{"tenant_name": "x"}, env: "y", expected: "x"},
+		{raw: map[string]interface{}{"project_name": "x"}, env: "y", expected: "x"},
+		{raw: map[string]interface{}{}, env: "y", expected: "y"},
+		{raw: map[string]interface{}{"tenant_name": "x", "project_name": "x"}, expected: "x"},
+		{raw: map[string]interface{}{"tenant_name": "x", "project_name": "y"}, err: true},
+	}
+
+	for i, c := range cases {
+		os.Setenv("OS_PROJECT_NAME", c.env)
+		d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, c.raw)
+		v, err := projectAlias(d, "project_name", "tenant_name")
+		if c.err {
+			if err == nil {
+				t.Errorf("case %d: expected an error, got %q", i, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d: %s", i, err)
+		} else if v != c.expected {
+			t.Errorf("case %d: expected %q, got %q", i, c.expected, v)
+		}
+	}
+}
+
 // Steps for configuring HuaweiCloud with SSL validation are here:
 // https://github.com/hashicorp/terraform/pull/6279#issuecomment-219020144
 func TestAccProvider_caCertFile(t *testing.T) {
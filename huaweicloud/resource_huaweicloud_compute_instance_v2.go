@@ -51,16 +51,18 @@ func resourceComputeInstanceV2() *schema.Resource {
 				ForceNew: false,
 			},
 			"image_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressRebuiltImageDiff,
 			},
 			"image_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressRebuiltImageDiff,
 			},
 			"flavor_id": &schema.Schema{
 				Type:        schema.TypeString,
@@ -87,15 +89,7 @@ func resourceComputeInstanceV2() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 				// just stash the hash for state & diff comparisons
-				StateFunc: func(v interface{}) string {
-					switch v.(type) {
-					case string:
-						hash := sha1.Sum([]byte(v.(string)))
-						return hex.EncodeToString(hash[:])
-					default:
-						return ""
-					}
-				},
+				StateFunc: hashInstanceUserData,
 			},
 			"security_groups": &schema.Schema{
 				Type:     schema.TypeSet,
@@ -329,6 +323,38 @@ func resourceComputeInstanceV2() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"rebuild": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"block_device"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"image_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"user_data": &schema.Schema{
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"personality"},
+							StateFunc:     hashInstanceUserData,
+						},
+					},
+				},
+			},
+			"power_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "active",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"active", "shutoff", "soft-reboot", "hard-reboot"})
+				},
+			},
 			"all_metadata": &schema.Schema{
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -462,6 +488,13 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 			server.ID, err)
 	}
 
+	if d.Get("power_state").(string) == "shutoff" {
+		err = setInstancePowerState(computeClient, d.Id(), "shutoff", "ACTIVE", d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceComputeInstanceV2Read(d, meta)
 }
 
@@ -481,6 +514,17 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 
 	d.Set("name", server.Name)
 
+	// A reboot ends with an active instance, keep the configured reboot
+	// so that it is not triggered again.
+	switch server.Status {
+	case "SHUTOFF":
+		d.Set("power_state", "shutoff")
+	case "ACTIVE":
+		if ps := d.Get("power_state").(string); ps != "soft-reboot" && ps != "hard-reboot" {
+			d.Set("power_state", "active")
+		}
+	}
+
 	// Get the instance network and address information
 	networks, err := flattenInstanceNetworks(d, meta)
 	if err != nil {
@@ -721,6 +765,70 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if d.HasChange("rebuild") && len(d.Get("rebuild").([]interface{})) > 0 {
+		rebuild := d.Get("rebuild").([]interface{})[0].(map[string]interface{})
+
+		// The image of the instance is kept unless another one is given.
+		imageId := d.Get("image_id").(string)
+		if v := rebuild["image_id"].(string); v != "" {
+			imageId = v
+		} else if v := rebuild["image_name"].(string); v != "" {
+			imageId, err = images.IDFromName(computeClient, v)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Ports, fixed IPs and attached volumes are kept by a rebuild.
+		rebuildOpts := &ServerRebuildOpts{
+			RebuildOpts: servers.RebuildOpts{
+				ImageID:   imageId,
+				AdminPass: d.Get("admin_pass").(string),
+			},
+		}
+
+		// Only the hash of user_data is kept in the state, the user data of
+		// the server is left as is unless it was changed.
+		if d.HasChange("rebuild.0.user_data") {
+			userData := rebuild["user_data"].(string)
+			rebuildOpts.UserData = &userData
+		} else {
+			rebuildOpts.Personality = resourceInstancePersonalityV2(d)
+		}
+
+		log.Printf("[DEBUG] Rebuilding instance (%s) with image %s", d.Id(), imageId)
+		err = rebuildServer(computeClient, d.Id(), rebuildOpts)
+		if err != nil {
+			return fmt.Errorf("Error rebuilding HuaweiCloud server (%s): %s", d.Id(), err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"REBUILD"},
+			Target:     []string{"ACTIVE", "SHUTOFF"},
+			Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("Error waiting for instance (%s) to rebuild: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("power_state") {
+		server, err := servers.Get(computeClient, d.Id()).Extract()
+		if err != nil {
+			return CheckDeleted(d, err, "server")
+		}
+
+		err = setInstancePowerState(computeClient, d.Id(), d.Get("power_state").(string), server.Status, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceComputeInstanceV2Read(d, meta)
 }
 
@@ -797,6 +905,90 @@ func ServerV2StateRefreshFunc(client *gophercloud.ServiceClient, instanceID stri
 	}
 }
 
+// rebuildServer rebuilds the server. User data can only be passed from
+// compute microversion 2.57 on, which no longer accepts personality files.
+func rebuildServer(client *gophercloud.ServiceClient, instanceID string, opts *ServerRebuildOpts) error {
+	if opts.UserData != nil {
+		c := *client
+		c.Microversion = "2.57"
+		client = &c
+	}
+	return servers.Rebuild(client, instanceID, opts).Err
+}
+
+// hashInstanceUserData stashes the hash of the user data in the state.
+func hashInstanceUserData(v interface{}) string {
+	switch v.(type) {
+	case string:
+		hash := sha1.Sum([]byte(v.(string)))
+		return hex.EncodeToString(hash[:])
+	default:
+		return ""
+	}
+}
+
+// suppressRebuiltImageDiff keeps an instance with a rebuild block from being
+// replaced because its image is no longer the one it was created with.
+func suppressRebuiltImageDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && len(d.Get("rebuild").([]interface{})) > 0
+}
+
+// setInstancePowerState starts, stops or reboots the instance and waits for
+// it to reach the state matching powerState. status is its current status.
+func setInstancePowerState(client *gophercloud.ServiceClient, instanceID, powerState, status string, timeout time.Duration) error {
+	var pending []string
+	target := []string{"ACTIVE"}
+
+	switch {
+	case powerState == "shutoff":
+		if status == "SHUTOFF" {
+			return nil
+		}
+		log.Printf("[DEBUG] Stopping instance (%s)", instanceID)
+		if err := startstop.Stop(client, instanceID).ExtractErr(); err != nil {
+			return fmt.Errorf("Error stopping HuaweiCloud server (%s): %s", instanceID, err)
+		}
+		pending = []string{"ACTIVE"}
+		target = []string{"SHUTOFF"}
+	case status == "SHUTOFF":
+		// Starting a stopped instance is enough to reboot it.
+		log.Printf("[DEBUG] Starting instance (%s)", instanceID)
+		if err := startstop.Start(client, instanceID).ExtractErr(); err != nil {
+			return fmt.Errorf("Error starting HuaweiCloud server (%s): %s", instanceID, err)
+		}
+		pending = []string{"SHUTOFF"}
+	case powerState == "soft-reboot" || powerState == "hard-reboot":
+		rebootOpts := &servers.RebootOpts{
+			Type: servers.SoftReboot,
+		}
+		if powerState == "hard-reboot" {
+			rebootOpts.Type = servers.HardReboot
+		}
+		log.Printf("[DEBUG] Rebooting instance (%s): %#v", instanceID, rebootOpts)
+		if err := servers.Reboot(client, instanceID, rebootOpts).ExtractErr(); err != nil {
+			return fmt.Errorf("Error rebooting HuaweiCloud server (%s): %s", instanceID, err)
+		}
+		pending = []string{"REBOOT", "HARD_REBOOT"}
+	default:
+		return nil
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    ServerV2StateRefreshFunc(client, instanceID),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to become %s: %s", instanceID, powerState, err)
+	}
+	return nil
+}
+
 func resourceInstanceSecGroupsV2(d *schema.ResourceData) []string {
 	rawSecGroups := d.Get("security_groups").(*schema.Set).List()
 	secgroups := make([]string, len(rawSecGroups))
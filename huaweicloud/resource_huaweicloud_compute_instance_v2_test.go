@@ -1,7 +1,11 @@
 package huaweicloud
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -224,6 +228,201 @@ func TestAccComputeV2Instance_stopBeforeDestroy(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Instance_powerState(t *testing.T) {
+	var instance servers.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Instance_powerState("shutoff"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance),
+					testAccCheckComputeV2InstanceStatus(&instance, "SHUTOFF"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_compute_instance_v2.instance_1", "power_state", "shutoff"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccComputeV2Instance_powerState("active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance),
+					testAccCheckComputeV2InstanceStatus(&instance, "ACTIVE"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_compute_instance_v2.instance_1", "power_state", "active"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccComputeV2Instance_powerState("hard-reboot"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance),
+					testAccCheckComputeV2InstanceStatus(&instance, "ACTIVE"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_compute_instance_v2.instance_1", "power_state", "hard-reboot"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2Instance_rebuild(t *testing.T) {
+	var instance1, instance2 servers.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Instance_rebuild("#cloud-config\nhostname: instance_1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance1),
+				),
+			},
+			resource.TestStep{
+				Config: testAccComputeV2Instance_rebuildUserData("#cloud-config\nhostname: instance_1_rebuilt"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance2),
+					testAccCheckComputeV2InstanceSameServer(&instance1, &instance2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2Instance_rebuildImage(t *testing.T) {
+	var instance1, instance2 servers.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckRebuild(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Instance_rebuild("#cloud-config\nhostname: instance_1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance1),
+				),
+			},
+			resource.TestStep{
+				Config: testAccComputeV2Instance_rebuildImage("#cloud-config\nhostname: instance_1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance2),
+					testAccCheckComputeV2InstanceSameServer(&instance1, &instance2),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_compute_instance_v2.instance_1", "image_id", OS_REBUILD_IMAGE_ID),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_compute_instance_v2.instance_1", "user_data",
+						"dda91b52bfe43e5dac141701d719edbc46f52c61"),
+				),
+			},
+		},
+	})
+}
+
+// Without a rebuild block, changing the user data replaces the instance.
+func TestAccComputeV2Instance_replaceUserData(t *testing.T) {
+	var instance1, instance2 servers.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Instance_rebuild("#cloud-config\nhostname: instance_1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance1),
+				),
+			},
+			resource.TestStep{
+				Config: testAccComputeV2Instance_rebuild("#cloud-config\nhostname: instance_2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("huaweicloud_compute_instance_v2.instance_1", &instance2),
+					func(*terraform.State) error {
+						if instance1.ID == instance2.ID {
+							return fmt.Errorf("Instance was not replaced")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestRebuildServer_userData(t *testing.T) {
+	var header http.Header
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers/instance/action" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		header = r.Header
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"server": {"id": "instance"}}`)
+	}))
+	defer server.Close()
+
+	client := &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       server.URL + "/",
+		Type:           "compute",
+	}
+
+	// User data is passed with microversion 2.57, without personality.
+	userData := "#cloud-config"
+	err := rebuildServer(client, "instance", &ServerRebuildOpts{
+		RebuildOpts: servers.RebuildOpts{ImageID: "image"},
+		UserData:    &userData,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := header.Get("X-OpenStack-Nova-API-Version"); v != "2.57" {
+		t.Fatalf("expected microversion 2.57, got %q", v)
+	}
+	expected := map[string]interface{}{
+		"rebuild": map[string]interface{}{"imageRef": "image", "user_data": "I2Nsb3VkLWNvbmZpZw=="},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, body)
+	}
+	if client.Microversion != "" {
+		t.Fatalf("the microversion of the client was changed to %s", client.Microversion)
+	}
+
+	// Removed user data is sent as null.
+	userData = ""
+	if err := rebuildServer(client, "instance", &ServerRebuildOpts{
+		RebuildOpts: servers.RebuildOpts{ImageID: "image"},
+		UserData:    &userData,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := body["rebuild"].(map[string]interface{})["user_data"]; !ok || v != nil {
+		t.Fatalf("expected user_data to be null, got %#v", body)
+	}
+
+	// The user data is left as is otherwise.
+	if err := rebuildServer(client, "instance", &ServerRebuildOpts{
+		RebuildOpts: servers.RebuildOpts{ImageID: "image"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if v := header.Get("X-OpenStack-Nova-API-Version"); v != "" {
+		t.Fatalf("expected no microversion, got %q", v)
+	}
+	if _, ok := body["rebuild"].(map[string]interface{})["user_data"]; ok {
+		t.Fatalf("unexpected user_data: %#v", body)
+	}
+}
+
 func TestAccComputeV2Instance_metadataRemove(t *testing.T) {
 	var instance servers.Server
 
@@ -435,6 +634,32 @@ func testAccCheckComputeV2InstanceInstanceIDsDoNotMatch(
 	}
 }
 
+func testAccCheckComputeV2InstanceSameServer(
+	instance1, instance2 *servers.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if instance1.ID != instance2.ID {
+			return fmt.Errorf("Instance was recreated.")
+		}
+
+		if instance1.AccessIPv4 != instance2.AccessIPv4 {
+			return fmt.Errorf("Instance address changed from %s to %s", instance1.AccessIPv4, instance2.AccessIPv4)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckComputeV2InstanceStatus(
+	instance *servers.Server, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if instance.Status != status {
+			return fmt.Errorf("Bad status for instance %s: expected %s, got %s", instance.ID, status, instance.Status)
+		}
+
+		return nil
+	}
+}
+
 var testAccComputeV2Instance_basic = fmt.Sprintf(`
 resource "huaweicloud_compute_instance_v2" "instance_1" {
   name = "instance_1"
@@ -674,6 +899,70 @@ resource "huaweicloud_compute_instance_v2" "instance_1" {
 }
 `, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
 
+func testAccComputeV2Instance_powerState(powerState string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  power_state = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, OS_AVAILABILITY_ZONE, powerState, OS_NETWORK_ID)
+}
+
+func testAccComputeV2Instance_rebuild(userData string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  user_data = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, OS_AVAILABILITY_ZONE, userData, OS_NETWORK_ID)
+}
+
+// The user data the instance was created with is kept in the configuration,
+// the instance would be replaced otherwise.
+func testAccComputeV2Instance_rebuildUserData(userData string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  user_data = "#cloud-config\nhostname: instance_1"
+  rebuild {
+    user_data = "%s"
+  }
+  network {
+    uuid = "%s"
+  }
+}
+`, OS_AVAILABILITY_ZONE, userData, OS_NETWORK_ID)
+}
+
+func testAccComputeV2Instance_rebuildImage(userData string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  user_data = "%s"
+  rebuild {
+    image_id = "%s"
+  }
+  network {
+    uuid = "%s"
+  }
+}
+`, OS_AVAILABILITY_ZONE, userData, OS_REBUILD_IMAGE_ID, OS_NETWORK_ID)
+}
+
 var testAccComputeV2Instance_metadataRemove_1 = fmt.Sprintf(`
 resource "huaweicloud_compute_instance_v2" "instance_1" {
   name = "instance_1"
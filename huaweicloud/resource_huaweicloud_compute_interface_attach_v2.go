@@ -0,0 +1,267 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceComputeInterfaceAttachV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeInterfaceAttachV2Create,
+		Read:   resourceComputeInterfaceAttachV2Read,
+		Delete: resourceComputeInterfaceAttachV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"instance_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"port_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"network_id"},
+			},
+
+			"network_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"port_id"},
+			},
+
+			"fixed_ip": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"port_id"},
+			},
+
+			"mac": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeInterfaceAttachV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud compute client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	portId := d.Get("port_id").(string)
+	networkId := d.Get("network_id").(string)
+
+	if portId == "" && networkId == "" {
+		return fmt.Errorf("One of port_id or network_id must be set")
+	}
+
+	attachOpts := attachinterfaces.CreateOpts{
+		PortID:    portId,
+		NetworkID: networkId,
+	}
+
+	if v, ok := d.GetOk("fixed_ip"); ok {
+		attachOpts.FixedIPs = []attachinterfaces.FixedIP{
+			{IPAddress: v.(string)},
+		}
+	}
+
+	log.Printf("[DEBUG] Creating interface attachment: %#v", attachOpts)
+
+	attachment, err := attachinterfaces.Create(computeClient, instanceId, attachOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error attaching HuaweiCloud interface: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ATTACHING"},
+		Target:     []string{"ATTACHED"},
+		Refresh:    resourceComputeInterfaceAttachV2AttachFunc(computeClient, instanceId, attachment.PortID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err = stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error attaching HuaweiCloud interface: %s", err)
+	}
+
+	log.Printf("[DEBUG] Created interface attachment: %#v", attachment)
+
+	// Use the instance ID and port ID as the resource ID.
+	// This is because an interface can only be retrieved through its instance.
+	id := fmt.Sprintf("%s/%s", instanceId, attachment.PortID)
+
+	d.SetId(id)
+
+	return resourceComputeInterfaceAttachV2Read(d, meta)
+}
+
+func resourceComputeInterfaceAttachV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud compute client: %s", err)
+	}
+
+	instanceId, portId, err := parseComputeInterfaceAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	attachment, err := attachinterfaces.Get(computeClient, instanceId, portId).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "compute_interface_attach")
+	}
+
+	log.Printf("[DEBUG] Retrieved interface attachment: %#v", attachment)
+
+	d.Set("instance_id", instanceId)
+	d.Set("port_id", attachment.PortID)
+	d.Set("network_id", attachment.NetID)
+	d.Set("mac", attachment.MACAddr)
+	if len(attachment.FixedIPs) > 0 {
+		d.Set("fixed_ip", attachment.FixedIPs[0].IPAddress)
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceComputeInterfaceAttachV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud compute client: %s", err)
+	}
+
+	instanceId, portId, err := parseComputeInterfaceAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{""},
+		Target:     []string{"DETACHED"},
+		Refresh:    resourceComputeInterfaceAttachV2DetachFunc(computeClient, instanceId, portId),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err = stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error detaching HuaweiCloud interface: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeInterfaceAttachV2AttachFunc(
+	computeClient *gophercloud.ServiceClient, instanceId, portId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		attachment, err := attachinterfaces.Get(computeClient, instanceId, portId).Extract()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return attachment, "ATTACHING", nil
+			}
+			return attachment, "", err
+		}
+
+		// The port is bound to the instance once it is ACTIVE, or DOWN if
+		// the instance is stopped.
+		switch attachment.PortState {
+		case "ACTIVE":
+		case "DOWN":
+			server, err := servers.Get(computeClient, instanceId).Extract()
+			if err != nil {
+				return attachment, "", err
+			}
+			if server.Status != "SHUTOFF" {
+				return attachment, "ATTACHING", nil
+			}
+		default:
+			return attachment, "ATTACHING", nil
+		}
+
+		return attachment, "ATTACHED", nil
+	}
+}
+
+func resourceComputeInterfaceAttachV2DetachFunc(
+	computeClient *gophercloud.ServiceClient, instanceId, portId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Attempting to detach HuaweiCloud interface %s from instance %s",
+			portId, instanceId)
+
+		attachment, err := attachinterfaces.Get(computeClient, instanceId, portId).Extract()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return attachment, "DETACHED", nil
+			}
+			return attachment, "", err
+		}
+
+		err = attachinterfaces.Delete(computeClient, instanceId, portId).ExtractErr()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return attachment, "DETACHED", nil
+			}
+
+			if _, ok := err.(gophercloud.ErrDefault400); ok {
+				return nil, "", nil
+			}
+
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] HuaweiCloud interface attachment (%s) is still active.", portId)
+		return nil, "", nil
+	}
+}
+
+func parseComputeInterfaceAttachmentId(id string) (string, string, error) {
+	idParts := strings.Split(id, "/")
+	if len(idParts) < 2 {
+		return "", "", fmt.Errorf("Unable to determine interface attachment ID")
+	}
+
+	instanceId := idParts[0]
+	portId := idParts[1]
+
+	return instanceId, portId, nil
+}
@@ -0,0 +1,265 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
+)
+
+func TestComputeInterfaceAttachV2AttachFunc_stoppedInstance(t *testing.T) {
+	serverStatus := "ACTIVE"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/servers/instance/os-interface/port":
+			fmt.Fprint(w, `{"interfaceAttachment": {"port_id": "port", "port_state": "DOWN"}}`)
+		case "/servers/instance":
+			fmt.Fprintf(w, `{"server": {"id": "instance", "status": "%s"}}`, serverStatus)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       server.URL + "/",
+	}
+	refresh := resourceComputeInterfaceAttachV2AttachFunc(client, "instance", "port")
+
+	// A DOWN port of a running instance is still being bound.
+	if _, state, err := refresh(); err != nil || state != "ATTACHING" {
+		t.Fatalf("expected ATTACHING, got %q (%v)", state, err)
+	}
+
+	// The ports of a stopped instance stay DOWN.
+	serverStatus = "SHUTOFF"
+	if _, state, err := refresh(); err != nil || state != "ATTACHED" {
+		t.Fatalf("expected ATTACHED, got %q (%v)", state, err)
+	}
+}
+
+func TestAccComputeV2InterfaceAttach_basicPort(t *testing.T) {
+	var ai attachinterfaces.Interface
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InterfaceAttachDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2InterfaceAttach_basicPort,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InterfaceAttachExists("huaweicloud_compute_interface_attach_v2.ai_1", &ai),
+					resource.TestCheckResourceAttrPair(
+						"huaweicloud_compute_interface_attach_v2.ai_1", "port_id",
+						"huaweicloud_networking_port_v2.port_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2InterfaceAttach_basicNetwork(t *testing.T) {
+	var ai attachinterfaces.Interface
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InterfaceAttachDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2InterfaceAttach_basicNetwork,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InterfaceAttachExists("huaweicloud_compute_interface_attach_v2.ai_1", &ai),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_compute_instance_v2.instance_1", "network.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2InterfaceAttach_IP(t *testing.T) {
+	var ai attachinterfaces.Interface
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InterfaceAttachDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2InterfaceAttach_IP,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InterfaceAttachExists("huaweicloud_compute_interface_attach_v2.ai_1", &ai),
+					testAccCheckComputeV2InterfaceAttachIP(&ai, "192.168.199.24"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeV2InterfaceAttachDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	computeClient, err := config.computeV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud compute client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_compute_interface_attach_v2" {
+			continue
+		}
+
+		instanceId, portId, err := parseComputeInterfaceAttachmentId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = attachinterfaces.Get(computeClient, instanceId, portId).Extract()
+		if err == nil {
+			return fmt.Errorf("Interface attachment still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckComputeV2InterfaceAttachExists(n string, ai *attachinterfaces.Interface) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		computeClient, err := config.computeV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud compute client: %s", err)
+		}
+
+		instanceId, portId, err := parseComputeInterfaceAttachmentId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := attachinterfaces.Get(computeClient, instanceId, portId).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.PortID != portId {
+			return fmt.Errorf("InterfaceAttach not found")
+		}
+
+		*ai = *found
+
+		return nil
+	}
+}
+
+func testAccCheckComputeV2InterfaceAttachIP(
+	ai *attachinterfaces.Interface, ip string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, i := range ai.FixedIPs {
+			if i.IPAddress == ip {
+				return nil
+			}
+		}
+		return fmt.Errorf("Requested ip (%s) does not exist on port", ip)
+	}
+}
+
+var testAccComputeV2InterfaceAttach_basicPort = fmt.Sprintf(`
+resource "huaweicloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "huaweicloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+}
+
+resource "huaweicloud_networking_port_v2" "port_1" {
+  name = "port_1"
+  admin_state_up = "true"
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+
+  fixed_ip {
+    subnet_id = "${huaweicloud_networking_subnet_v2.subnet_1.id}"
+  }
+}
+
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "huaweicloud_compute_interface_attach_v2" "ai_1" {
+  instance_id = "${huaweicloud_compute_instance_v2.instance_1.id}"
+  port_id = "${huaweicloud_networking_port_v2.port_1.id}"
+}
+`, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
+
+var testAccComputeV2InterfaceAttach_basicNetwork = fmt.Sprintf(`
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "huaweicloud_compute_interface_attach_v2" "ai_1" {
+  instance_id = "${huaweicloud_compute_instance_v2.instance_1.id}"
+  network_id = "%s"
+}
+`, OS_AVAILABILITY_ZONE, OS_NETWORK_ID, OS_NETWORK_ID)
+
+var testAccComputeV2InterfaceAttach_IP = fmt.Sprintf(`
+resource "huaweicloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "huaweicloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+}
+
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "huaweicloud_compute_interface_attach_v2" "ai_1" {
+  instance_id = "${huaweicloud_compute_instance_v2.instance_1.id}"
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+  fixed_ip = "192.168.199.24"
+}
+`, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
@@ -3,6 +3,7 @@ package huaweicloud
 import (
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -34,6 +35,24 @@ func resourceComputeKeypairV2() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
+				Computed: true,
+			},
+			"private_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"private_key_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"public_key"},
+			},
+			"kms_key_name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"public_key"},
 			},
 			"value_specs": &schema.Schema{
 				Type:     schema.TypeMap,
@@ -67,9 +86,51 @@ func resourceComputeKeypairV2Create(d *schema.ResourceData, meta interface{}) er
 
 	d.SetId(kp.Name)
 
+	// The private key is only returned when the key pair is generated.
+	if kp.PrivateKey != "" {
+		d.Set("private_key", kp.PrivateKey)
+
+		if path, ok := d.GetOk("private_key_file"); ok {
+			if err := writeKeypairPrivateKey(path.(string), kp.PrivateKey); err != nil {
+				return fmt.Errorf("Error writing private key of HuaweiCloud keypair %s to %s: %s", kp.Name, path, err)
+			}
+		}
+
+		if kmsKeyName, ok := d.GetOk("kms_key_name"); ok {
+			kpsClient, err := config.kmsKeyPairV3Client(GetRegion(d, config))
+			if err != nil {
+				return fmt.Errorf("Error creating HuaweiCloud kms key pair client: %s", err)
+			}
+
+			log.Printf("[DEBUG] Storing private key of keypair %s with KMS key %s", kp.Name, kmsKeyName)
+			err = importKmsKeyPairPrivateKey(kpsClient, kp.Name, kp.PrivateKey, kmsKeyName.(string))
+			if err != nil {
+				return fmt.Errorf("Error storing private key of HuaweiCloud keypair %s: %s", kp.Name, err)
+			}
+		}
+	}
+
 	return resourceComputeKeypairV2Read(d, meta)
 }
 
+// writeKeypairPrivateKey writes privateKey to path, which is only readable by
+// its owner even if it already existed.
+func writeKeypairPrivateKey(path, privateKey string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.WriteString(privateKey); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func resourceComputeKeypairV2Read(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	computeClient, err := config.computeV2Client(GetRegion(d, config))
@@ -100,6 +161,13 @@ func resourceComputeKeypairV2Delete(d *schema.ResourceData, meta interface{}) er
 	if err != nil {
 		return fmt.Errorf("Error deleting HuaweiCloud keypair: %s", err)
 	}
+
+	if path, ok := d.GetOk("private_key_file"); ok {
+		if err := os.Remove(path.(string)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Error removing private key file %s: %s", path, err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
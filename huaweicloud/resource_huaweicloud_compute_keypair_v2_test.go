@@ -2,8 +2,12 @@ package huaweicloud
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 
@@ -28,6 +32,86 @@ func TestAccComputeV2Keypair_basic(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Keypair_privateKey(t *testing.T) {
+	var keypair keypairs.KeyPair
+	dir, err := ioutil.TempDir("", "tf-acc-keypair")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	privateKeyFile := filepath.Join(dir, "kp_1.pem")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2KeypairDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Keypair_privateKey(privateKeyFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2KeypairExists("huaweicloud_compute_keypair_v2.kp_1", &keypair),
+					resource.TestCheckResourceAttrSet(
+						"huaweicloud_compute_keypair_v2.kp_1", "public_key"),
+					resource.TestCheckResourceAttrSet(
+						"huaweicloud_compute_keypair_v2.kp_1", "private_key"),
+					testAccCheckComputeV2KeypairPrivateKeyFile("huaweicloud_compute_keypair_v2.kp_1", privateKeyFile),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2Keypair_kms(t *testing.T) {
+	var keypair keypairs.KeyPair
+	keyAlias := fmt.Sprintf("kms_%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2KeypairDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Keypair_kms(keyAlias),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2KeypairExists("huaweicloud_compute_keypair_v2.kp_1", &keypair),
+					resource.TestCheckResourceAttrSet(
+						"huaweicloud_compute_keypair_v2.kp_1", "private_key"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_compute_keypair_v2.kp_1", "kms_key_name", keyAlias),
+				),
+			},
+		},
+	})
+}
+
+func TestWriteKeypairPrivateKey_existingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-keypair")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "id_rsa")
+	if err := ioutil.WriteFile(path, []byte("a much longer previous content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeKeypairPrivateKey(path, "private key"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected mode 0600, got %o", info.Mode().Perm())
+	}
+	if b, _ := ioutil.ReadFile(path); string(b) != "private key" {
+		t.Fatalf("unexpected content: %q", b)
+	}
+}
+
 func testAccCheckComputeV2KeypairDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	computeClient, err := config.computeV2Client(OS_REGION_NAME)
@@ -81,9 +165,59 @@ func testAccCheckComputeV2KeypairExists(n string, kp *keypairs.KeyPair) resource
 	}
 }
 
+func testAccCheckComputeV2KeypairPrivateKeyFile(n, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if fi.Mode().Perm() != 0600 {
+			return fmt.Errorf("Expected mode 0600 for %s, got %o", path, fi.Mode().Perm())
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if string(content) != rs.Primary.Attributes["private_key"] {
+			return fmt.Errorf("Private key file %s does not match the private key", path)
+		}
+
+		return nil
+	}
+}
+
 const testAccComputeV2Keypair_basic = `
 resource "huaweicloud_compute_keypair_v2" "kp_1" {
   name = "kp_1"
   public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDAjpC1hwiOCCmKEWxJ4qzTTsJbKzndLo1BCz5PcwtUnflmU+gHJtWMZKpuEGVi29h0A/+ydKek1O18k10Ff+4tyFjiHDQAT9+OfgWf7+b1yK+qDip3X1C0UPMbwHlTfSGWLGZquwhvEFx9k3h/M+VtMvwR1lJ9LUyTAImnNjWG7TAIPmui30HvM2UiFEmqkr4ijq45MyX2+fLIePLRIFuu1p4whjHAQYufqyno3BS48icQb4p6iVEZPo4AE2o9oIyQvj2mx4dk5Y8CgSETOZTYDOR3rU2fZTRDRgPJDH9FWvQjF5tA0p3d9CoWWd2s6GKKbfoUIi8R/Db1BSPJwkqB jrp-hp-pc"
 }
 `
+
+func testAccComputeV2Keypair_privateKey(path string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_compute_keypair_v2" "kp_1" {
+  name = "kp_1"
+  private_key_file = "%s"
+}
+`, path)
+}
+
+func testAccComputeV2Keypair_kms(keyAlias string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_kms_key_v1" "key_1" {
+  key_alias = "%s"
+  pending_days = "7"
+}
+
+resource "huaweicloud_compute_keypair_v2" "kp_1" {
+  name = "kp_1"
+  kms_key_name = "${huaweicloud_kms_key_v1.key_1.key_alias}"
+}
+`, keyAlias)
+}
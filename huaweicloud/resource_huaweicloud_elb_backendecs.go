@@ -5,7 +5,6 @@ import (
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/elb"
@@ -106,17 +105,14 @@ func resourceELBBackendECSCreate(d *schema.ResourceData, meta interface{}) error
 	log.Printf("[DEBUG] Create %s Options: %#v", nameELBBackend, createOpts)
 
 	lId := d.Get("listener_id").(string)
-	j, err := backendecs.Create(networkingClient, createOpts, lId).Extract()
-	if err != nil {
-		return fmt.Errorf("Error creating %s: %s", nameELBBackend, err)
-	}
-	log.Printf("[DEBUG] Create %s, the job is: %#v", nameELBBackend, *j)
-
 	// Wait for BackendECS to become active before continuing
-	timeout := d.Timeout(schema.TimeoutCreate)
-	jobInfo, err := waitForELBJobSuccess(networkingClient, j, timeout)
+	ctx, cancel := timeoutContext(d, schema.TimeoutCreate)
+	defer cancel()
+	jobInfo, err := newELBJobTracker(networkingClient).Run(ctx, elbJob(func() (*elb.Job, error) {
+		return backendecs.Create(networkingClient, createOpts, lId).Extract()
+	}))
 	if err != nil {
-		return err
+		return fmt.Errorf("Error creating %s: %s", nameELBBackend, err)
 	}
 	log.Printf("[DEBUG] Create %s, the job info is: %#v", nameELBBackend, jobInfo)
 
@@ -176,17 +172,12 @@ func resourceELBBackendECSDelete(d *schema.ResourceData, meta interface{}) error
 	}
 	log.Printf("[DEBUG] Deleting %s option: %#v", nameELBBackend, deleteOpts)
 
-	var job *elb.Job
-	timeout := d.Timeout(schema.TimeoutDelete)
+	ctx, cancel := timeoutContext(d, schema.TimeoutDelete)
+	defer cancel()
 	lId := d.Get("listener_id").(string)
-	err = resource.Retry(timeout, func() *resource.RetryError {
-		j, err := backendecs.Delete(networkingClient, lId, deleteOpts).Extract()
-		if err != nil {
-			return checkForRetryableError(err)
-		}
-		job = j
-		return nil
-	})
+	_, err = newELBJobTracker(networkingClient).RunWithRetry(ctx, elbJob(func() (*elb.Job, error) {
+		return backendecs.Delete(networkingClient, lId, deleteOpts).Extract()
+	}))
 	if err != nil {
 		if isResourceNotFound(err) {
 			log.Printf("[INFO] deleting an unavailable %s: %s", nameELBBackend, bId)
@@ -194,11 +185,5 @@ func resourceELBBackendECSDelete(d *schema.ResourceData, meta interface{}) error
 		}
 		return fmt.Errorf("Error deleting %s(%s): %s", nameELBBackend, bId, err)
 	}
-	log.Printf("[DEBUG] Delete %s, the job is: %#v", nameELBBackend, *job)
-
-	_, err = waitForELBJobSuccess(networkingClient, job, timeout)
-	if err != nil {
-		return err
-	}
 	return nil
 }
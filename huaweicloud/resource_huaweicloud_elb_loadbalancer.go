@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/elb"
@@ -208,17 +207,14 @@ func resourceELBLoadBalancerCreate(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("tenantid is mandatory when type is set to Internal")
 	}
 
-	j, err := loadbalancers.Create(networkingClient, opts).Extract()
-	if err != nil {
-		return fmt.Errorf("Error creating %s: %s", nameELBLB, err)
-	}
-	log.Printf("[DEBUG] Create %s, the job is: %#v", nameELBLB, *j)
-
 	// Wait for LoadBalancer to become active before continuing
-	timeout := d.Timeout(schema.TimeoutCreate)
-	jobInfo, err := waitForELBJobSuccess(networkingClient, j, timeout)
+	ctx, cancel := timeoutContext(d, schema.TimeoutCreate)
+	defer cancel()
+	jobInfo, err := newELBJobTracker(networkingClient).Run(ctx, elbJob(func() (*elb.Job, error) {
+		return loadbalancers.Create(networkingClient, opts).Extract()
+	}))
 	if err != nil {
-		return err
+		return fmt.Errorf("Error creating %s: %s", nameELBLB, err)
 	}
 	log.Printf("[DEBUG] Create %s, the job is: %#v", nameELBLB, jobInfo)
 
@@ -280,25 +276,15 @@ func resourceELBLoadBalancerUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	log.Printf("[DEBUG] Updating %s %s with options: %#v", nameELBLB, lbId, updateOpts)
-	var job *elb.Job
-	err = resource.Retry(timeout, func() *resource.RetryError {
-		j, err := loadbalancers.Update(networkingClient, lbId, updateOpts, not_pass_param).Extract()
-		if err != nil {
-			return checkForRetryableError(err)
-		}
-		job = j
-		return nil
-	})
+	ctx, cancel := timeoutContext(d, schema.TimeoutUpdate)
+	defer cancel()
+	_, err = newELBJobTracker(networkingClient).RunWithRetry(ctx, elbJob(func() (*elb.Job, error) {
+		return loadbalancers.Update(networkingClient, lbId, updateOpts, not_pass_param).Extract()
+	}))
 	if err != nil {
 		return fmt.Errorf("Error updating %s %s: %s", nameELBLB, lbId, err)
 	}
 
-	// Wait for LoadBalancer to become active before continuing
-	_, err = waitForELBJobSuccess(networkingClient, job, timeout)
-	if err != nil {
-		return err
-	}
-
 	return resourceELBLoadBalancerRead(d, meta)
 }
 
@@ -312,16 +298,11 @@ func resourceELBLoadBalancerDelete(d *schema.ResourceData, meta interface{}) err
 	lbId := d.Id()
 	log.Printf("[DEBUG] Deleting %s %s", nameELBLB, lbId)
 
-	var job *elb.Job
-	timeout := d.Timeout(schema.TimeoutDelete)
-	err = resource.Retry(timeout, func() *resource.RetryError {
-		j, err := loadbalancers.Delete(networkingClient, lbId).Extract()
-		if err != nil {
-			return checkForRetryableError(err)
-		}
-		job = j
-		return nil
-	})
+	ctx, cancel := timeoutContext(d, schema.TimeoutDelete)
+	defer cancel()
+	_, err = newELBJobTracker(networkingClient).RunWithRetry(ctx, elbJob(func() (*elb.Job, error) {
+		return loadbalancers.Delete(networkingClient, lbId).Extract()
+	}))
 	if err != nil {
 		if isResourceNotFound(err) {
 			log.Printf("[INFO] deleting an unavailable %s: %s", nameELBLB, lbId)
@@ -329,11 +310,5 @@ func resourceELBLoadBalancerDelete(d *schema.ResourceData, meta interface{}) err
 		}
 		return fmt.Errorf("Error deleting %s %s: %s", nameELBLB, lbId, err)
 	}
-	log.Printf("[DEBUG] Delete %s, the job is: %#v", nameELBLB, *job)
-
-	_, err = waitForELBJobSuccess(networkingClient, job, timeout)
-	if err != nil {
-		return err
-	}
 	return nil
 }
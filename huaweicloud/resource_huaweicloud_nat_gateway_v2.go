@@ -92,15 +92,10 @@ func resourceNatGatewayV2Create(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[DEBUG] Waiting for HuaweiCloud Nat Gateway (%s) to become available.", natGateway.ID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"ACTIVE"},
-		Refresh:    waitForNatGatewayActive(natV2Client, natGateway.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	ctx, cancel := timeoutContext(d, schema.TimeoutCreate)
+	defer cancel()
+	_, err = waitForStatus(ctx, "Nat Gateway "+natGateway.ID, nil, []string{"ACTIVE"},
+		waitForNatGatewayActive(natV2Client, natGateway.ID))
 	if err != nil {
 		return fmt.Errorf("Error creating HuaweiCloud Nat Gateway: %s", err)
 	}
@@ -170,16 +165,10 @@ func resourceNatGatewayV2Delete(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating HuaweiCloud nat client: %s", err)
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"ACTIVE"},
-		Target:     []string{"DELETED"},
-		Refresh:    waitForNatGatewayDelete(natV2Client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	ctx, cancel := timeoutContext(d, schema.TimeoutDelete)
+	defer cancel()
+	_, err = waitForStatus(ctx, "Nat Gateway "+d.Id(), []string{"ACTIVE"}, []string{"DELETED"},
+		waitForNatGatewayDelete(natV2Client, d.Id()))
 	if err != nil {
 		return fmt.Errorf("Error deleting HuaweiCloud Nat Gateway: %s", err)
 	}
@@ -196,8 +185,8 @@ func waitForNatGatewayActive(natV2Client *golangsdk.ServiceClient, nId string) r
 		}
 
 		log.Printf("[DEBUG] HuaweiCloud Nat Gateway: %+v", n)
-		if n.Status == "ACTIVE" {
-			return n, "ACTIVE", nil
+		if n.Status == "ACTIVE" || n.Status == "ERROR" {
+			return n, n.Status, nil
 		}
 
 		return n, "", nil
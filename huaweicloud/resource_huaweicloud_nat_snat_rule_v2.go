@@ -70,15 +70,10 @@ func resourceNatSnatRuleV2Create(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[DEBUG] Waiting for HuaweiCloud Snat Rule (%s) to become available.", snatRule.ID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"ACTIVE"},
-		Refresh:    waitForSnatRuleActive(natV2Client, snatRule.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	ctx, cancel := timeoutContext(d, schema.TimeoutCreate)
+	defer cancel()
+	_, err = waitForStatus(ctx, "Snat Rule "+snatRule.ID, nil, []string{"ACTIVE"},
+		waitForSnatRuleActive(natV2Client, snatRule.ID))
 	if err != nil {
 		return fmt.Errorf("Error creating HuaweiCloud Snat Rule: %s", err)
 	}
@@ -116,16 +111,10 @@ func resourceNatSnatRuleV2Delete(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating HuaweiCloud nat client: %s", err)
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"ACTIVE"},
-		Target:     []string{"DELETED"},
-		Refresh:    waitForSnatRuleDelete(natV2Client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	ctx, cancel := timeoutContext(d, schema.TimeoutDelete)
+	defer cancel()
+	_, err = waitForStatus(ctx, "Snat Rule "+d.Id(), []string{"ACTIVE"}, []string{"DELETED"},
+		waitForSnatRuleDelete(natV2Client, d.Id()))
 	if err != nil {
 		return fmt.Errorf("Error deleting HuaweiCloud Snat Rule: %s", err)
 	}
@@ -142,8 +131,8 @@ func waitForSnatRuleActive(natV2Client *golangsdk.ServiceClient, nId string) res
 		}
 
 		log.Printf("[DEBUG] HuaweiCloud Snat Rule: %+v", n)
-		if n.Status == "ACTIVE" {
-			return n, "ACTIVE", nil
+		if n.Status == "ACTIVE" || n.Status == "ERROR" {
+			return n, n.Status, nil
 		}
 
 		return n, "", nil
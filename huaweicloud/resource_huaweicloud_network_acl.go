@@ -0,0 +1,367 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/firewall_groups"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/routerinsertion"
+)
+
+// networkACLDeviceOwner is the owner of the port binding a VPC subnet to its
+// router, network ACLs are applied on these ports.
+const networkACLDeviceOwner = "network:router_interface_distributed"
+
+func resourceNetworkACL() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkACLCreate,
+		Read:   resourceNetworkACLRead,
+		Update: resourceNetworkACLUpdate,
+		Delete: resourceNetworkACLDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"inbound_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"outbound_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"subnets": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"ports": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkACLCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	fwClient, err := config.fwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud fw client: %s", err)
+	}
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	portIds, err := resourceNetworkACLPorts(networkingClient, expandStringSet(d.Get("subnets").(*schema.Set)))
+	if err != nil {
+		return err
+	}
+
+	createOpts := routerinsertion.CreateOptsExt{
+		CreateOptsBuilder: firewall_groups.CreateOpts{
+			Name:            d.Get("name").(string),
+			Description:     d.Get("description").(string),
+			IngressPolicyID: d.Get("inbound_policy_id").(string),
+			EgressPolicyID:  d.Get("outbound_policy_id").(string),
+		},
+		PortIDs: portIds,
+	}
+
+	log.Printf("[DEBUG] Create network ACL: %#v", createOpts)
+
+	acl, err := firewall_groups.Create(fwClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud network ACL: %s", err)
+	}
+
+	log.Printf("[DEBUG] Network ACL created: %#v", acl)
+	d.SetId(acl.ID)
+
+	if err := waitForNetworkACLReady(d, fwClient, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceNetworkACLRead(d, meta)
+}
+
+func resourceNetworkACLRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Retrieve information about network ACL: %s", d.Id())
+
+	config := meta.(*Config)
+	fwClient, err := config.fwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud fw client: %s", err)
+	}
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	var acl FirewallGroup
+	err = firewall_groups.Get(fwClient, d.Id()).ExtractInto(&acl)
+	if err != nil {
+		return CheckDeleted(d, err, "network ACL")
+	}
+
+	log.Printf("[DEBUG] Read HuaweiCloud network ACL %s: %#v", d.Id(), acl)
+
+	subnets, err := resourceNetworkACLSubnets(networkingClient, acl.PortIDs)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", acl.Name)
+	d.Set("description", acl.Description)
+	d.Set("inbound_policy_id", acl.IngressPolicyID)
+	d.Set("outbound_policy_id", acl.EgressPolicyID)
+	d.Set("status", acl.Status)
+	if err := d.Set("subnets", subnets); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving subnets to state for HuaweiCloud network ACL (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("ports", acl.PortIDs); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving ports to state for HuaweiCloud network ACL (%s): %s", d.Id(), err)
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceNetworkACLUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	fwClient, err := config.fwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud fw client: %s", err)
+	}
+
+	var baseOpts firewall_groups.UpdateOpts
+	if d.HasChange("name") {
+		baseOpts.Name = d.Get("name").(string)
+	}
+
+	opts := networkACLUpdateOpts{UpdateOptsBuilder: baseOpts}
+	if d.HasChange("description") {
+		v := d.Get("description").(string)
+		opts.Description = &v
+	}
+	if d.HasChange("inbound_policy_id") {
+		v := d.Get("inbound_policy_id").(string)
+		opts.IngressPolicyID = &v
+	}
+	if d.HasChange("outbound_policy_id") {
+		v := d.Get("outbound_policy_id").(string)
+		opts.EgressPolicyID = &v
+	}
+
+	var updateOpts firewall_groups.UpdateOptsBuilder = opts
+	if d.HasChange("subnets") {
+		networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+		}
+
+		portIds, err := resourceNetworkACLPorts(networkingClient, expandStringSet(d.Get("subnets").(*schema.Set)))
+		if err != nil {
+			return err
+		}
+		updateOpts = routerinsertion.UpdateOptsExt{
+			UpdateOptsBuilder: opts,
+			PortIDs:           portIds,
+		}
+	}
+
+	log.Printf("[DEBUG] Updating network ACL with id %s: %#v", d.Id(), updateOpts)
+
+	err = firewall_groups.Update(fwClient, d.Id(), updateOpts).Err
+	if err != nil {
+		return fmt.Errorf("Error updating HuaweiCloud network ACL: %s", err)
+	}
+
+	if err := waitForNetworkACLReady(d, fwClient, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+
+	return resourceNetworkACLRead(d, meta)
+}
+
+// networkACLUpdateOpts adds the description and the policies to
+// firewall_groups.UpdateOpts, which leaves out empty values: a cleared
+// description is sent as "" and a removed policy as null.
+type networkACLUpdateOpts struct {
+	firewall_groups.UpdateOptsBuilder
+	Description     *string
+	IngressPolicyID *string
+	EgressPolicyID  *string
+}
+
+func (opts networkACLUpdateOpts) ToFirewallGroupUpdateMap() (map[string]interface{}, error) {
+	base, err := opts.UpdateOptsBuilder.ToFirewallGroupUpdateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	firewallMap := base["firewall_group"].(map[string]interface{})
+	if opts.Description != nil {
+		firewallMap["description"] = *opts.Description
+	}
+	for key, v := range map[string]*string{
+		"ingress_firewall_policy_id": opts.IngressPolicyID,
+		"egress_firewall_policy_id":  opts.EgressPolicyID,
+	} {
+		switch {
+		case v == nil:
+		case *v == "":
+			firewallMap[key] = nil
+		default:
+			firewallMap[key] = *v
+		}
+	}
+
+	return base, nil
+}
+
+func resourceNetworkACLDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy network ACL: %s", d.Id())
+
+	config := meta.(*Config)
+	fwClient, err := config.fwV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud fw client: %s", err)
+	}
+
+	// The subnets have to be disassociated before the ACL can be deleted.
+	if len(d.Get("ports").(*schema.Set).List()) > 0 {
+		updateOpts := routerinsertion.UpdateOptsExt{
+			UpdateOptsBuilder: firewall_groups.UpdateOpts{},
+			PortIDs:           []string{},
+		}
+		err = firewall_groups.Update(fwClient, d.Id(), updateOpts).Err
+		if err != nil {
+			return CheckDeleted(d, err, "network ACL")
+		}
+
+		if err := waitForNetworkACLReady(d, fwClient, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
+		}
+	}
+
+	err = firewall_groups.Delete(fwClient, d.Id()).Err
+	if err != nil {
+		return CheckDeleted(d, err, "network ACL")
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"DELETING"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForFirewallGroupDeletion(fwClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      0,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for network ACL (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// waitForNetworkACLReady waits for the network ACL to leave its PENDING_*
+// status, an ACL without subnets is INACTIVE.
+func waitForNetworkACLReady(d *schema.ResourceData, fwClient *golangsdk.ServiceClient, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"PENDING_CREATE", "PENDING_UPDATE"},
+		Target:     []string{"ACTIVE", "INACTIVE"},
+		Refresh:    waitForFirewallGroupActive(fwClient, d.Id()),
+		Timeout:    timeout,
+		Delay:      0,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for network ACL (%s) to become ready: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// resourceNetworkACLPorts resolves the VPC subnets to the router interface
+// ports the ACL is applied on.
+func resourceNetworkACLPorts(networkingClient *gophercloud.ServiceClient, subnets []string) ([]string, error) {
+	portIds := make([]string, 0, len(subnets))
+	for _, subnetId := range subnets {
+		listOpts := ports.ListOpts{
+			NetworkID:   subnetId,
+			DeviceOwner: networkACLDeviceOwner,
+		}
+		pages, err := ports.List(networkingClient, listOpts).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("Error listing router interface ports of subnet %s: %s", subnetId, err)
+		}
+		allPorts, err := ports.ExtractPorts(pages)
+		if err != nil {
+			return nil, fmt.Errorf("Error extracting router interface ports of subnet %s: %s", subnetId, err)
+		}
+		if len(allPorts) == 0 {
+			return nil, fmt.Errorf("No router interface port found for subnet %s", subnetId)
+		}
+		portIds = append(portIds, allPorts[0].ID)
+	}
+
+	log.Printf("[DEBUG] Resolved subnets %v to ports %v", subnets, portIds)
+	return portIds, nil
+}
+
+// resourceNetworkACLSubnets maps the ports of the ACL back to their subnets.
+func resourceNetworkACLSubnets(networkingClient *gophercloud.ServiceClient, portIds []string) ([]string, error) {
+	subnets := make([]string, 0, len(portIds))
+	for _, portId := range portIds {
+		port, err := ports.Get(networkingClient, portId).Extract()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				log.Printf("[DEBUG] Port %s of network ACL not found", portId)
+				continue
+			}
+			return nil, fmt.Errorf("Error retrieving port %s: %s", portId, err)
+		}
+		subnets = append(subnets, port.NetworkID)
+	}
+	return subnets, nil
+}
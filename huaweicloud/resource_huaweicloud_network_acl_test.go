@@ -0,0 +1,258 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/firewall_groups"
+)
+
+func TestAccNetworkACL_basic(t *testing.T) {
+	var acl FirewallGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkACLDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkACL_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLExists("huaweicloud_network_acl.acl_1", &acl),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "name", "acl_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "subnets.#", "1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "ports.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"huaweicloud_network_acl.acl_1", "inbound_policy_id",
+						"huaweicloud_fw_policy_v2.policy_1", "id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkACL_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLExists("huaweicloud_network_acl.acl_1", &acl),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "name", "acl_1_updated"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "description", "network acl"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "subnets.#", "2"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "ports.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"huaweicloud_network_acl.acl_1", "outbound_policy_id",
+						"huaweicloud_fw_policy_v2.policy_2", "id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkACL_noSubnets,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLExists("huaweicloud_network_acl.acl_1", &acl),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "subnets.#", "0"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "description", ""),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "status", "INACTIVE"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkACL_noPolicies,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLExists("huaweicloud_network_acl.acl_1", &acl),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "inbound_policy_id", ""),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_network_acl.acl_1", "outbound_policy_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestNetworkACLUpdateOpts_removePolicy(t *testing.T) {
+	ingress, egress := "", "policy"
+	opts := networkACLUpdateOpts{
+		UpdateOptsBuilder: firewall_groups.UpdateOpts{Name: "acl"},
+		IngressPolicyID:   &ingress,
+		EgressPolicyID:    &egress,
+	}
+	b, err := opts.ToFirewallGroupUpdateMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"firewall_group": map[string]interface{}{
+			"name":                       "acl",
+			"ingress_firewall_policy_id": nil,
+			"egress_firewall_policy_id":  "policy",
+		},
+	}
+	if !reflect.DeepEqual(b, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, b)
+	}
+}
+
+func TestNetworkACLUpdateOpts_clearDescription(t *testing.T) {
+	description := ""
+	opts := networkACLUpdateOpts{
+		UpdateOptsBuilder: firewall_groups.UpdateOpts{},
+		Description:       &description,
+	}
+	b, err := opts.ToFirewallGroupUpdateMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"firewall_group": map[string]interface{}{
+			"description": "",
+		},
+	}
+	if !reflect.DeepEqual(b, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, b)
+	}
+}
+
+func testAccCheckNetworkACLDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	fwClient, err := config.fwV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud fw client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_network_acl" {
+			continue
+		}
+		_, err = firewall_groups.Get(fwClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Network ACL (%s) still exists.", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccCheckNetworkACLExists(n string, acl *FirewallGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		fwClient, err := config.fwV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud fw client: %s", err)
+		}
+
+		var found FirewallGroup
+		err = firewall_groups.Get(fwClient, rs.Primary.ID).ExtractInto(&found)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Network ACL not found")
+		}
+
+		*acl = found
+
+		return nil
+	}
+}
+
+var testAccNetworkACL_network = fmt.Sprintf(`
+resource "huaweicloud_vpc_v1" "vpc_1" {
+  name = "vpc_acl"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_vpc_subnet_v1" "subnet_1" {
+  name = "subnet_acl_1"
+  cidr = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+  vpc_id = "${huaweicloud_vpc_v1.vpc_1.id}"
+  availability_zone = "%s"
+}
+
+resource "huaweicloud_vpc_subnet_v1" "subnet_2" {
+  name = "subnet_acl_2"
+  cidr = "192.168.1.0/24"
+  gateway_ip = "192.168.1.1"
+  vpc_id = "${huaweicloud_vpc_v1.vpc_1.id}"
+  availability_zone = "%s"
+}
+
+resource "huaweicloud_fw_rule_v2" "rule_1" {
+  protocol = "tcp"
+  destination_port = "22"
+  action = "allow"
+}
+
+resource "huaweicloud_fw_policy_v2" "policy_1" {
+  name = "acl_inbound"
+  rules = ["${huaweicloud_fw_rule_v2.rule_1.id}"]
+}
+
+resource "huaweicloud_fw_policy_v2" "policy_2" {
+  name = "acl_outbound"
+}
+`, OS_AVAILABILITY_ZONE, OS_AVAILABILITY_ZONE)
+
+var testAccNetworkACL_basic = fmt.Sprintf(`
+%s
+
+resource "huaweicloud_network_acl" "acl_1" {
+  name = "acl_1"
+  inbound_policy_id = "${huaweicloud_fw_policy_v2.policy_1.id}"
+  subnets = ["${huaweicloud_vpc_subnet_v1.subnet_1.id}"]
+}
+`, testAccNetworkACL_network)
+
+var testAccNetworkACL_update = fmt.Sprintf(`
+%s
+
+resource "huaweicloud_network_acl" "acl_1" {
+  name = "acl_1_updated"
+  description = "network acl"
+  inbound_policy_id = "${huaweicloud_fw_policy_v2.policy_1.id}"
+  outbound_policy_id = "${huaweicloud_fw_policy_v2.policy_2.id}"
+  subnets = [
+    "${huaweicloud_vpc_subnet_v1.subnet_1.id}",
+    "${huaweicloud_vpc_subnet_v1.subnet_2.id}",
+  ]
+}
+`, testAccNetworkACL_network)
+
+var testAccNetworkACL_noSubnets = fmt.Sprintf(`
+%s
+
+resource "huaweicloud_network_acl" "acl_1" {
+  name = "acl_1_updated"
+  inbound_policy_id = "${huaweicloud_fw_policy_v2.policy_1.id}"
+  outbound_policy_id = "${huaweicloud_fw_policy_v2.policy_2.id}"
+}
+`, testAccNetworkACL_network)
+
+var testAccNetworkACL_noPolicies = fmt.Sprintf(`
+%s
+
+resource "huaweicloud_network_acl" "acl_1" {
+  name = "acl_1_updated"
+}
+`, testAccNetworkACL_network)
@@ -77,6 +77,11 @@ func resourceNetworkingSecGroupRuleV2() *schema.Resource {
 					return strings.ToLower(v.(string))
 				},
 			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 			"security_group_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -110,13 +115,16 @@ func resourceNetworkingSecGroupRuleV2Create(d *schema.ResourceData, meta interfa
 		}
 	}
 
-	opts := rules.CreateOpts{
-		SecGroupID:     d.Get("security_group_id").(string),
-		PortRangeMin:   d.Get("port_range_min").(int),
-		PortRangeMax:   d.Get("port_range_max").(int),
-		RemoteGroupID:  d.Get("remote_group_id").(string),
-		RemoteIPPrefix: d.Get("remote_ip_prefix").(string),
-		TenantID:       d.Get("tenant_id").(string),
+	opts := secGroupRuleCreateOpts{
+		CreateOpts: rules.CreateOpts{
+			SecGroupID:     d.Get("security_group_id").(string),
+			PortRangeMin:   d.Get("port_range_min").(int),
+			PortRangeMax:   d.Get("port_range_max").(int),
+			RemoteGroupID:  d.Get("remote_group_id").(string),
+			RemoteIPPrefix: d.Get("remote_ip_prefix").(string),
+			TenantID:       d.Get("tenant_id").(string),
+		},
+		Description: d.Get("description").(string),
 	}
 
 	if v, ok := d.GetOk("direction"); ok {
@@ -136,7 +144,7 @@ func resourceNetworkingSecGroupRuleV2Create(d *schema.ResourceData, meta interfa
 
 	log.Printf("[DEBUG] Create HuaweiCloud Neutron security group: %#v", opts)
 
-	security_group_rule, err := rules.Create(networkingClient, opts).Extract()
+	security_group_rule, err := createSecGroupRule(networkingClient, opts)
 	if err != nil {
 		return err
 	}
@@ -157,7 +165,7 @@ func resourceNetworkingSecGroupRuleV2Read(d *schema.ResourceData, meta interface
 		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
 	}
 
-	security_group_rule, err := rules.Get(networkingClient, d.Id()).Extract()
+	security_group_rule, err := getSecGroupRule(networkingClient, d.Id())
 
 	if err != nil {
 		return CheckDeleted(d, err, "HuaweiCloud Security Group Rule")
@@ -170,6 +178,7 @@ func resourceNetworkingSecGroupRuleV2Read(d *schema.ResourceData, meta interface
 	d.Set("port_range_max", security_group_rule.PortRangeMax)
 	d.Set("remote_group_id", security_group_rule.RemoteGroupID)
 	d.Set("remote_ip_prefix", security_group_rule.RemoteIPPrefix)
+	d.Set("description", security_group_rule.Description)
 	d.Set("security_group_id", security_group_rule.SecGroupID)
 	d.Set("tenant_id", security_group_rule.TenantID)
 	d.Set("region", GetRegion(d, config))
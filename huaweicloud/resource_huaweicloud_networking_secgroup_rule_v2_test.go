@@ -33,6 +33,8 @@ func TestAccNetworkingV2SecGroupRule_basic(t *testing.T) {
 						"huaweicloud_networking_secgroup_rule_v2.secgroup_rule_1", &secgroup_rule_1),
 					testAccCheckNetworkingV2SecGroupRuleExists(
 						"huaweicloud_networking_secgroup_rule_v2.secgroup_rule_2", &secgroup_rule_2),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_secgroup_rule_v2.secgroup_rule_1", "description", "ssh"),
 				),
 			},
 		},
@@ -180,6 +182,7 @@ resource "huaweicloud_networking_secgroup_rule_v2" "secgroup_rule_1" {
   port_range_min = 22
   protocol = "tcp"
   remote_ip_prefix = "0.0.0.0/0"
+  description = "ssh"
   security_group_id = "${huaweicloud_networking_secgroup_v2.secgroup_1.id}"
 }
 
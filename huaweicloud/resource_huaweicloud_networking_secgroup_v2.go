@@ -54,6 +54,13 @@ func resourceNetworkingSecGroupV2() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"rule": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     secGroupRuleSchema(),
+				Set:      resourceSecGroupRuleHash,
+			},
 		},
 	}
 }
@@ -98,6 +105,13 @@ func resourceNetworkingSecGroupV2Create(d *schema.ResourceData, meta interface{}
 
 	d.SetId(security_group.ID)
 
+	// The inline rules replace all the rules of the group, the default ones too.
+	if v, ok := d.GetOk("rule"); ok {
+		if err := syncSecGroupRules(networkingClient, security_group.ID, v.(*schema.Set).List()); err != nil {
+			return err
+		}
+	}
+
 	return resourceNetworkingSecGroupV2Read(d, meta)
 }
 
@@ -121,6 +135,14 @@ func resourceNetworkingSecGroupV2Read(d *schema.ResourceData, meta interface{})
 	d.Set("name", security_group.Name)
 	d.Set("region", GetRegion(d, config))
 
+	secGroupRules, err := listSecGroupRules(networkingClient, rules.ListOpts{SecGroupID: d.Id()})
+	if err != nil {
+		return fmt.Errorf("Error listing the rules of security group %s: %s", d.Id(), err)
+	}
+	if err := d.Set("rule", flattenSecGroupRules(secGroupRules)); err != nil {
+		return fmt.Errorf("Error setting rule: %s", err)
+	}
+
 	return nil
 }
 
@@ -152,6 +174,12 @@ func resourceNetworkingSecGroupV2Update(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChange("rule") {
+		if err := syncSecGroupRules(networkingClient, d.Id(), d.Get("rule").(*schema.Set).List()); err != nil {
+			return err
+		}
+	}
+
 	return resourceNetworkingSecGroupV2Read(d, meta)
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
 )
 
 func TestAccNetworkingV2SecGroup_basic(t *testing.T) {
@@ -59,6 +60,94 @@ func TestAccNetworkingV2SecGroup_noDefaultRules(t *testing.T) {
 	})
 }
 
+func TestAccNetworkingV2SecGroup_rules(t *testing.T) {
+	var security_group groups.SecGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SecGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2SecGroup_rules,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(
+						"huaweicloud_networking_secgroup_v2.secgroup_1", &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 2),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_secgroup_v2.secgroup_1", "rule.#", "2"),
+				),
+			},
+			resource.TestStep{
+				// A rule added out of band is removed.
+				PreConfig: testAccAddNetworkingV2SecGroupRule(&security_group),
+				Config:    testAccNetworkingV2SecGroup_rulesUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(
+						"huaweicloud_networking_secgroup_v2.secgroup_1", &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 2),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_secgroup_v2.secgroup_1", "rule.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestDiffSecGroupRules(t *testing.T) {
+	rule := func(id string, port int, description string) secGroupRule {
+		return secGroupRule{
+			SecGroupRule: rules.SecGroupRule{
+				ID:             id,
+				Direction:      "ingress",
+				EtherType:      "IPv4",
+				Protocol:       "tcp",
+				PortRangeMin:   port,
+				PortRangeMax:   port,
+				RemoteIPPrefix: "0.0.0.0/0",
+			},
+			Description: description,
+		}
+	}
+	current := []secGroupRule{rule("1", 22, "ssh"), rule("2", 80, "http"), rule("3", 8080, "")}
+	desired := []interface{}{
+		flattenSecGroupRule(rule("", 22, "ssh")),
+		flattenSecGroupRule(rule("", 80, "web")),
+		flattenSecGroupRule(rule("", 443, "https")),
+	}
+	desired[0].(map[string]interface{})["protocol"] = "TCP"
+
+	create, remove := diffSecGroupRules(current, desired)
+	if len(create) != 2 || create[0]["port_range_min"] != 80 || create[1]["port_range_min"] != 443 {
+		t.Fatalf("expected to create the rules of ports 80 and 443, got %v", create)
+	}
+	if len(remove) != 2 || remove[0].ID != "2" || remove[1].ID != "3" {
+		t.Fatalf("expected to remove the rules 2 and 3, got %v", remove)
+	}
+}
+
+func testAccAddNetworkingV2SecGroupRule(sg *groups.SecGroup) func() {
+	return func() {
+		config := testAccProvider.Meta().(*Config)
+		networkingClient, err := config.networkingV2Client(OS_REGION_NAME)
+		if err != nil {
+			panic(err)
+		}
+		opts := rules.CreateOpts{
+			SecGroupID:     sg.ID,
+			Direction:      rules.DirIngress,
+			EtherType:      rules.EtherType4,
+			Protocol:       rules.ProtocolTCP,
+			PortRangeMin:   3389,
+			PortRangeMax:   3389,
+			RemoteIPPrefix: "0.0.0.0/0",
+		}
+		if _, err := rules.Create(networkingClient, opts).Extract(); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func TestAccNetworkingV2SecGroup_timeout(t *testing.T) {
 	var security_group groups.SecGroup
 
@@ -175,3 +264,47 @@ resource "huaweicloud_networking_secgroup_v2" "secgroup_1" {
   }
 }
 `
+
+const testAccNetworkingV2SecGroup_rules = `
+resource "huaweicloud_networking_secgroup_v2" "secgroup_1" {
+  name = "security_group_1"
+  description = "terraform security group acceptance test"
+
+  rule {
+    direction = "ingress"
+    ethertype = "IPv4"
+    protocol = "tcp"
+    port_range_min = 22
+    port_range_max = 22
+    remote_ip_prefix = "0.0.0.0/0"
+    description = "ssh"
+  }
+
+  rule {
+    direction = "egress"
+    ethertype = "IPv4"
+  }
+}
+`
+
+const testAccNetworkingV2SecGroup_rulesUpdate = `
+resource "huaweicloud_networking_secgroup_v2" "secgroup_1" {
+  name = "security_group_1"
+  description = "terraform security group acceptance test"
+
+  rule {
+    direction = "ingress"
+    ethertype = "IPv4"
+    protocol = "tcp"
+    port_range_min = 2222
+    port_range_max = 2222
+    remote_ip_prefix = "0.0.0.0/0"
+    description = "ssh"
+  }
+
+  rule {
+    direction = "egress"
+    ethertype = "IPv4"
+  }
+}
+`
@@ -0,0 +1,239 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+func resourceNetworkingVIPAssociateV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkingVIPAssociateV2Create,
+		Read:   resourceNetworkingVIPAssociateV2Read,
+		Update: resourceNetworkingVIPAssociateV2Update,
+		Delete: resourceNetworkingVIPAssociateV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNetworkingVIPAssociateV2Import,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"vip_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"port_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"vip_subnet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vip_ip_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingVIPAssociateV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	vipID := d.Get("vip_id").(string)
+	vip, err := ports.Get(networkingClient, vipID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving HuaweiCloud VIP %s: %s", vipID, err)
+	}
+	vipIP, err := vipIPAddress(vip)
+	if err != nil {
+		return err
+	}
+
+	for _, portID := range expandStringSet(d.Get("port_ids").(*schema.Set)) {
+		if err := associateVIPWithPort(networkingClient, portID, vipIP); err != nil {
+			return err
+		}
+	}
+	d.SetId(vipID)
+
+	return resourceNetworkingVIPAssociateV2Read(d, meta)
+}
+
+func resourceNetworkingVIPAssociateV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	vip, err := ports.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "VIP association")
+	}
+	vipIP, err := vipIPAddress(vip)
+	if err != nil {
+		return err
+	}
+
+	// The ports of the VIP are the ports of its network allowing its address.
+	allPages, err := ports.List(networkingClient, ports.ListOpts{NetworkID: vip.NetworkID}).AllPages()
+	if err != nil {
+		return fmt.Errorf("Error listing the ports of network %s: %s", vip.NetworkID, err)
+	}
+	allPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return fmt.Errorf("Error listing the ports of network %s: %s", vip.NetworkID, err)
+	}
+	var portIDs []string
+	for _, p := range allPorts {
+		if hasAddressPair(p.AllowedAddressPairs, vipIP) {
+			portIDs = append(portIDs, p.ID)
+		}
+	}
+	log.Printf("[DEBUG] Ports associated with VIP %s: %v", d.Id(), portIDs)
+
+	d.Set("vip_id", d.Id())
+	d.Set("port_ids", portIDs)
+	d.Set("vip_subnet_id", vip.FixedIPs[0].SubnetID)
+	d.Set("vip_ip_address", vipIP)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceNetworkingVIPAssociateV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	if d.HasChange("port_ids") {
+		vipIP := d.Get("vip_ip_address").(string)
+		o, n := d.GetChange("port_ids")
+		oldPorts, newPorts := o.(*schema.Set), n.(*schema.Set)
+
+		for _, portID := range expandStringSet(newPorts.Difference(oldPorts)) {
+			if err := associateVIPWithPort(networkingClient, portID, vipIP); err != nil {
+				return err
+			}
+		}
+		for _, portID := range expandStringSet(oldPorts.Difference(newPorts)) {
+			if err := disassociateVIPFromPort(networkingClient, portID, vipIP); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceNetworkingVIPAssociateV2Read(d, meta)
+}
+
+func resourceNetworkingVIPAssociateV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	vipIP := d.Get("vip_ip_address").(string)
+	for _, portID := range expandStringSet(d.Get("port_ids").(*schema.Set)) {
+		if err := disassociateVIPFromPort(networkingClient, portID, vipIP); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceNetworkingVIPAssociateV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("vip_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func vipIPAddress(vip *ports.Port) (string, error) {
+	if len(vip.FixedIPs) == 0 {
+		return "", fmt.Errorf("VIP %s has no IP address", vip.ID)
+	}
+	return vip.FixedIPs[0].IPAddress, nil
+}
+
+func hasAddressPair(pairs []ports.AddressPair, ip string) bool {
+	for _, pair := range pairs {
+		if pair.IPAddress == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// associateVIPWithPort allows the port to use the address of the VIP.
+func associateVIPWithPort(client *gophercloud.ServiceClient, portID, vipIP string) error {
+	osMutexKV.Lock(portID)
+	defer osMutexKV.Unlock(portID)
+
+	p, err := ports.Get(client, portID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving HuaweiCloud port %s: %s", portID, err)
+	}
+	if hasAddressPair(p.AllowedAddressPairs, vipIP) {
+		return nil
+	}
+
+	pairs := append(p.AllowedAddressPairs, ports.AddressPair{IPAddress: vipIP})
+	log.Printf("[DEBUG] Associating VIP %s with port %s", vipIP, portID)
+	_, err = ports.Update(client, portID, ports.UpdateOpts{AllowedAddressPairs: &pairs}).Extract()
+	if err != nil {
+		return fmt.Errorf("Error associating VIP %s with port %s: %s", vipIP, portID, err)
+	}
+	return nil
+}
+
+// disassociateVIPFromPort removes the address of the VIP from the allowed
+// address pairs of the port, a port which no longer exists is ignored.
+func disassociateVIPFromPort(client *gophercloud.ServiceClient, portID, vipIP string) error {
+	osMutexKV.Lock(portID)
+	defer osMutexKV.Unlock(portID)
+
+	p, err := ports.Get(client, portID).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("Error retrieving HuaweiCloud port %s: %s", portID, err)
+	}
+	if !hasAddressPair(p.AllowedAddressPairs, vipIP) {
+		return nil
+	}
+
+	pairs := make([]ports.AddressPair, 0, len(p.AllowedAddressPairs))
+	for _, pair := range p.AllowedAddressPairs {
+		if pair.IPAddress != vipIP {
+			pairs = append(pairs, pair)
+		}
+	}
+	log.Printf("[DEBUG] Disassociating VIP %s from port %s", vipIP, portID)
+	_, err = ports.Update(client, portID, ports.UpdateOpts{AllowedAddressPairs: &pairs}).Extract()
+	if err != nil {
+		return fmt.Errorf("Error disassociating VIP %s from port %s: %s", vipIP, portID, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+func TestAccNetworkingV2VIPAssociate_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-vip-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2VIPAssociateDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2VIPAssociate_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_vip_associate_v2.vip_associate_1", "port_ids.#", "2"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_vip_associate_v2.vip_associate_1", "vip_ip_address", "192.168.0.100"),
+					testAccCheckNetworkingV2VIPAssociated("huaweicloud_compute_instance_v2.instance_1", "192.168.0.100", true),
+					testAccCheckNetworkingV2VIPAssociated("huaweicloud_compute_instance_v2.instance_2", "192.168.0.100", true),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkingV2VIPAssociate_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_vip_associate_v2.vip_associate_1", "port_ids.#", "1"),
+					testAccCheckNetworkingV2VIPAssociated("huaweicloud_compute_instance_v2.instance_1", "192.168.0.100", true),
+					testAccCheckNetworkingV2VIPAssociated("huaweicloud_compute_instance_v2.instance_2", "192.168.0.100", false),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "huaweicloud_networking_vip_associate_v2.vip_associate_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2VIPAssociateDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	networkingClient, err := config.networkingV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_networking_vip_associate_v2" {
+			continue
+		}
+
+		vipIP := rs.Primary.Attributes["vip_ip_address"]
+		for k, portID := range rs.Primary.Attributes {
+			if !strings.HasPrefix(k, "port_ids.") || k == "port_ids.#" {
+				continue
+			}
+
+			p, err := ports.Get(networkingClient, portID).Extract()
+			if err == nil && hasAddressPair(p.AllowedAddressPairs, vipIP) {
+				return fmt.Errorf("VIP %s is still associated with port %s", vipIP, portID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNetworkingV2VIPAssociated(n, vipIP string, associated bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		networkingClient, err := config.networkingV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+		}
+
+		p, err := ports.Get(networkingClient, rs.Primary.Attributes["network.0.port"]).Extract()
+		if err != nil {
+			return err
+		}
+
+		if hasAddressPair(p.AllowedAddressPairs, vipIP) != associated {
+			return fmt.Errorf("Expected association of VIP %s with port %s to be %t", vipIP, p.ID, associated)
+		}
+
+		return nil
+	}
+}
+
+func testAccNetworkingV2VIPAssociate_base(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_networking_vip_v2" "vip_1" {
+  name       = "%s"
+  network_id = "${huaweicloud_vpc_subnet_v1.subnet_1.id}"
+  subnet_id  = "${huaweicloud_vpc_subnet_v1.subnet_1.subnet_id}"
+  ip_address = "192.168.0.100"
+}
+
+resource "huaweicloud_compute_instance_v2" "instance_1" {
+  name              = "%s-1"
+  security_groups   = ["default"]
+  availability_zone = "%s"
+  network {
+    uuid = "${huaweicloud_vpc_subnet_v1.subnet_1.id}"
+  }
+}
+
+resource "huaweicloud_compute_instance_v2" "instance_2" {
+  name              = "%s-2"
+  security_groups   = ["default"]
+  availability_zone = "%s"
+  network {
+    uuid = "${huaweicloud_vpc_subnet_v1.subnet_1.id}"
+  }
+}
+`, testAccNetworkingV2VIP_base(rName), rName, rName, OS_AVAILABILITY_ZONE, rName, OS_AVAILABILITY_ZONE)
+}
+
+func testAccNetworkingV2VIPAssociate_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_networking_vip_associate_v2" "vip_associate_1" {
+  vip_id   = "${huaweicloud_networking_vip_v2.vip_1.id}"
+  port_ids = [
+    "${huaweicloud_compute_instance_v2.instance_1.network.0.port}",
+    "${huaweicloud_compute_instance_v2.instance_2.network.0.port}",
+  ]
+}
+`, testAccNetworkingV2VIPAssociate_base(rName))
+}
+
+func testAccNetworkingV2VIPAssociate_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_networking_vip_associate_v2" "vip_associate_1" {
+  vip_id   = "${huaweicloud_networking_vip_v2.vip_1.id}"
+  port_ids = ["${huaweicloud_compute_instance_v2.instance_1.network.0.port}"]
+}
+`, testAccNetworkingV2VIPAssociate_base(rName))
+}
@@ -0,0 +1,186 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+// vipDeviceOwner is the device owner of the ports allocating virtual IPs.
+const vipDeviceOwner = "neutron:VIP_PORT"
+
+func resourceNetworkingVIPV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkingVIPV2Create,
+		Read:   resourceNetworkingVIPV2Read,
+		Update: resourceNetworkingVIPV2Update,
+		Delete: resourceNetworkingVIPV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"network_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"subnet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ip_address": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIP,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"mac_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingVIPV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	createOpts := ports.CreateOpts{
+		Name:        d.Get("name").(string),
+		NetworkID:   d.Get("network_id").(string),
+		DeviceOwner: vipDeviceOwner,
+		FixedIPs: []ports.IP{
+			{
+				SubnetID:  d.Get("subnet_id").(string),
+				IPAddress: d.Get("ip_address").(string),
+			},
+		},
+	}
+
+	log.Printf("[DEBUG] Create VIP Options: %#v", createOpts)
+	p, err := ports.Create(networkingClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud VIP: %s", err)
+	}
+	d.SetId(p.ID)
+	log.Printf("[INFO] VIP ID: %s", p.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"ACTIVE"},
+		Refresh:    waitForNetworkPortActive(networkingClient, p.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for VIP (%s) to become available: %s", p.ID, err)
+	}
+
+	return resourceNetworkingVIPV2Read(d, meta)
+}
+
+func resourceNetworkingVIPV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	p, err := ports.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "VIP")
+	}
+
+	log.Printf("[DEBUG] Retrieved VIP %s: %+v", d.Id(), p)
+
+	d.Set("name", p.Name)
+	d.Set("network_id", p.NetworkID)
+	d.Set("mac_address", p.MACAddress)
+	d.Set("status", p.Status)
+	if len(p.FixedIPs) > 0 {
+		d.Set("subnet_id", p.FixedIPs[0].SubnetID)
+		d.Set("ip_address", p.FixedIPs[0].IPAddress)
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceNetworkingVIPV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	if d.HasChange("name") {
+		updateOpts := ports.UpdateOpts{
+			Name: d.Get("name").(string),
+		}
+
+		log.Printf("[DEBUG] Updating VIP %s with options: %+v", d.Id(), updateOpts)
+		_, err = ports.Update(networkingClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating HuaweiCloud VIP: %s", err)
+		}
+	}
+
+	return resourceNetworkingVIPV2Read(d, meta)
+}
+
+func resourceNetworkingVIPV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForNetworkPortDelete(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error deleting HuaweiCloud VIP: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,149 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+func TestAccNetworkingV2VIP_basic(t *testing.T) {
+	var vip ports.Port
+	rName := fmt.Sprintf("tf-acc-vip-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2VIPDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2VIP_basic(rName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2VIPExists("huaweicloud_networking_vip_v2.vip_1", &vip),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_vip_v2.vip_1", "ip_address", "192.168.0.100"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_vip_v2.vip_1", "name", rName),
+					resource.TestCheckResourceAttrPair(
+						"huaweicloud_vpc_eip_v1.eip_1", "publicip.0.port_id",
+						"huaweicloud_networking_vip_v2.vip_1", "id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkingV2VIP_basic(rName, rName+"-update"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2VIPExists("huaweicloud_networking_vip_v2.vip_1", &vip),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_networking_vip_v2.vip_1", "name", rName+"-update"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "huaweicloud_networking_vip_v2.vip_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2VIPDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	networkingClient, err := config.networkingV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_networking_vip_v2" {
+			continue
+		}
+
+		_, err := ports.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("VIP still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNetworkingV2VIPExists(n string, vip *ports.Port) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		networkingClient, err := config.networkingV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud networking client: %s", err)
+		}
+
+		found, err := ports.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VIP not found")
+		}
+		if found.DeviceOwner != vipDeviceOwner {
+			return fmt.Errorf("Port %s is not a VIP: device owner is %s", found.ID, found.DeviceOwner)
+		}
+
+		*vip = *found
+
+		return nil
+	}
+}
+
+func testAccNetworkingV2VIP_base(rName string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc_v1" "vpc_1" {
+  name = "%s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_vpc_subnet_v1" "subnet_1" {
+  name       = "%s"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+  vpc_id     = "${huaweicloud_vpc_v1.vpc_1.id}"
+}
+`, rName, rName)
+}
+
+func testAccNetworkingV2VIP_basic(rName, vipName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_networking_vip_v2" "vip_1" {
+  name       = "%s"
+  network_id = "${huaweicloud_vpc_subnet_v1.subnet_1.id}"
+  subnet_id  = "${huaweicloud_vpc_subnet_v1.subnet_1.subnet_id}"
+  ip_address = "192.168.0.100"
+}
+
+resource "huaweicloud_vpc_eip_v1" "eip_1" {
+  publicip {
+    type    = "5_bgp"
+    port_id = "${huaweicloud_networking_vip_v2.vip_1.id}"
+  }
+  bandwidth {
+    name        = "%s"
+    size        = 5
+    share_type  = "PER"
+    charge_mode = "traffic"
+  }
+}
+`, testAccNetworkingV2VIP_base(rName), vipName, rName)
+}
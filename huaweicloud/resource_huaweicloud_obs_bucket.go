@@ -0,0 +1,434 @@
+package huaweicloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	obsAZRedundancyHeader = "X-Obs-Az-Redundancy"
+	obsMultiAZ            = "3az"
+)
+
+type obsCreateBucketConfiguration struct {
+	XMLName  xml.Name `xml:"CreateBucketConfiguration"`
+	Location string   `xml:"Location"`
+}
+
+type obsBucketStorageClass struct {
+	XMLName      xml.Name `xml:"StorageClass"`
+	StorageClass string   `xml:",chardata"`
+}
+
+type obsBucketQuota struct {
+	XMLName      xml.Name `xml:"Quota"`
+	StorageQuota int64    `xml:"StorageQuota"`
+}
+
+type obsBucketEncryption struct {
+	XMLName xml.Name `xml:"ServerSideEncryptionConfiguration"`
+	Rule    struct {
+		Default struct {
+			SSEAlgorithm   string `xml:"SSEAlgorithm"`
+			KMSMasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+		} `xml:"ApplyServerSideEncryptionByDefault"`
+	} `xml:"Rule"`
+}
+
+type obsObjectVersion struct {
+	Key       string `xml:"Key"`
+	VersionID string `xml:"VersionId"`
+}
+
+type obsListVersionsResult struct {
+	XMLName             xml.Name           `xml:"ListVersionsResult"`
+	IsTruncated         bool               `xml:"IsTruncated"`
+	NextKeyMarker       string             `xml:"NextKeyMarker"`
+	NextVersionIDMarker string             `xml:"NextVersionIdMarker"`
+	Versions            []obsObjectVersion `xml:"Version"`
+	DeleteMarkers       []obsObjectVersion `xml:"DeleteMarker"`
+}
+
+func resourceObsBucket() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceObsBucketCreate,
+		Read:   resourceObsBucketRead,
+		Update: resourceObsBucketUpdate,
+		Delete: resourceObsBucketDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceObsBucketImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"storage_class": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "STANDARD",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"STANDARD", "WARM", "COLD"})
+				},
+			},
+
+			"acl": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "private",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{
+						"private", "public-read", "public-read-write", "authenticated-read",
+						"bucket-owner-read", "bucket-owner-full-control", "log-delivery-write",
+					})
+				},
+			},
+
+			"quota": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative", k))
+					}
+					return
+				},
+			},
+
+			"multi_az": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"encryption": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"kms_key_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"force_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"bucket_domain_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceObsBucketCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	obsClient, err := config.obsClient(region)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud OBS client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	if err := validateS3BucketName(bucket, region); err != nil {
+		return fmt.Errorf("Error validating OBS bucket name: %s", err)
+	}
+
+	header := http.Header{}
+	header.Set("X-Obs-Acl", d.Get("acl").(string))
+	header.Set("X-Obs-Storage-Class", d.Get("storage_class").(string))
+	if d.Get("multi_az").(bool) {
+		header.Set(obsAZRedundancyHeader, obsMultiAZ)
+	}
+
+	log.Printf("[DEBUG] OBS bucket create: %s, region: %s, headers: %v", bucket, region, header)
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := obsClient.DoXML(&obsRequest{
+			Method: "PUT",
+			Bucket: bucket,
+			Header: header,
+		}, &obsCreateBucketConfiguration{Location: region}, nil)
+		if isOBSErrorCode(err, "OperationAborted") {
+			log.Printf("[WARN] Got an error while trying to create OBS bucket %s: %s", bucket, err)
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating OBS bucket %s: %s", bucket, err)
+	}
+
+	d.SetId(bucket)
+
+	if d.Get("quota").(int) != 0 {
+		if err := resourceObsBucketQuotaUpdate(obsClient, d); err != nil {
+			return err
+		}
+	}
+	if d.Get("encryption").(bool) {
+		if err := resourceObsBucketEncryptionUpdate(obsClient, d); err != nil {
+			return err
+		}
+	}
+
+	return resourceObsBucketRead(d, meta)
+}
+
+func resourceObsBucketRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	obsClient, err := config.obsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud OBS client: %s", err)
+	}
+
+	bucket := d.Id()
+	head, err := obsClient.Do(&obsRequest{Method: "HEAD", Bucket: bucket})
+	if err != nil {
+		if isOBSNotFound(err) {
+			log.Printf("[WARN] OBS bucket (%s) not found, removing from state", bucket)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading OBS bucket %s: %s", bucket, err)
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("multi_az", head.Header.Get(obsAZRedundancyHeader) == obsMultiAZ)
+	d.Set("bucket_domain_name", obsClient.BucketDomainName(bucket))
+	if location := head.Header.Get("X-Obs-Bucket-Location"); location != "" {
+		d.Set("region", location)
+	} else {
+		d.Set("region", GetRegion(d, config))
+	}
+
+	var storageClass obsBucketStorageClass
+	_, err = obsClient.DoXML(&obsRequest{
+		Method: "GET",
+		Bucket: bucket,
+		Query:  obsSubResource("storageClass"),
+	}, nil, &storageClass)
+	if err != nil {
+		return fmt.Errorf("Error reading storage class of OBS bucket %s: %s", bucket, err)
+	}
+	d.Set("storage_class", storageClass.StorageClass)
+
+	var quota obsBucketQuota
+	_, err = obsClient.DoXML(&obsRequest{
+		Method: "GET",
+		Bucket: bucket,
+		Query:  obsSubResource("quota"),
+	}, nil, &quota)
+	if err != nil {
+		return fmt.Errorf("Error reading quota of OBS bucket %s: %s", bucket, err)
+	}
+	d.Set("quota", int(quota.StorageQuota))
+
+	var encryption obsBucketEncryption
+	_, err = obsClient.DoXML(&obsRequest{
+		Method: "GET",
+		Bucket: bucket,
+		Query:  obsSubResource("encryption"),
+	}, nil, &encryption)
+	if err != nil {
+		if !isOBSNotFound(err, "NoSuchEncryptionConfiguration") {
+			return fmt.Errorf("Error reading encryption of OBS bucket %s: %s", bucket, err)
+		}
+		d.Set("encryption", false)
+		d.Set("kms_key_id", "")
+	} else {
+		d.Set("encryption", true)
+		d.Set("kms_key_id", encryption.Rule.Default.KMSMasterKeyID)
+	}
+
+	return nil
+}
+
+func resourceObsBucketUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	obsClient, err := config.obsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud OBS client: %s", err)
+	}
+
+	bucket := d.Id()
+	if d.HasChange("acl") {
+		header := http.Header{}
+		header.Set("X-Obs-Acl", d.Get("acl").(string))
+		_, err := obsClient.Do(&obsRequest{
+			Method: "PUT",
+			Bucket: bucket,
+			Query:  obsSubResource("acl"),
+			Header: header,
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating acl of OBS bucket %s: %s", bucket, err)
+		}
+	}
+
+	if d.HasChange("storage_class") {
+		_, err := obsClient.DoXML(&obsRequest{
+			Method: "PUT",
+			Bucket: bucket,
+			Query:  obsSubResource("storageClass"),
+		}, &obsBucketStorageClass{StorageClass: d.Get("storage_class").(string)}, nil)
+		if err != nil {
+			return fmt.Errorf("Error updating storage class of OBS bucket %s: %s", bucket, err)
+		}
+	}
+
+	if d.HasChange("quota") {
+		if err := resourceObsBucketQuotaUpdate(obsClient, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("encryption") || d.HasChange("kms_key_id") {
+		if err := resourceObsBucketEncryptionUpdate(obsClient, d); err != nil {
+			return err
+		}
+	}
+
+	return resourceObsBucketRead(d, meta)
+}
+
+func resourceObsBucketDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	obsClient, err := config.obsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud OBS client: %s", err)
+	}
+
+	bucket := d.Id()
+	log.Printf("[DEBUG] OBS Delete Bucket: %s", bucket)
+	_, err = obsClient.Do(&obsRequest{Method: "DELETE", Bucket: bucket})
+	if err != nil {
+		if isOBSNotFound(err) {
+			return nil
+		}
+		if isOBSErrorCode(err, "BucketNotEmpty") && d.Get("force_destroy").(bool) {
+			log.Printf("[DEBUG] OBS bucket %s attempting to force destroy", bucket)
+			if err := deleteAllObsObjectVersions(obsClient, bucket); err != nil {
+				return err
+			}
+			// Retry now the bucket has been emptied.
+			return resourceObsBucketDelete(d, meta)
+		}
+		return fmt.Errorf("Error deleting OBS bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func resourceObsBucketImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// A bucket imported from the state of a huaweicloud_s3_bucket keeps its
+	// name as ID, nothing else is needed to take it over.
+	d.Set("bucket", d.Id())
+	d.Set("force_destroy", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceObsBucketQuotaUpdate(obsClient *obsClient, d *schema.ResourceData) error {
+	bucket := d.Id()
+	_, err := obsClient.DoXML(&obsRequest{
+		Method: "PUT",
+		Bucket: bucket,
+		Query:  obsSubResource("quota"),
+	}, &obsBucketQuota{StorageQuota: int64(d.Get("quota").(int))}, nil)
+	if err != nil {
+		return fmt.Errorf("Error updating quota of OBS bucket %s: %s", bucket, err)
+	}
+	return nil
+}
+
+func resourceObsBucketEncryptionUpdate(obsClient *obsClient, d *schema.ResourceData) error {
+	bucket := d.Id()
+	if !d.Get("encryption").(bool) {
+		_, err := obsClient.Do(&obsRequest{
+			Method: "DELETE",
+			Bucket: bucket,
+			Query:  obsSubResource("encryption"),
+		})
+		if err != nil && !isOBSNotFound(err) {
+			return fmt.Errorf("Error disabling encryption of OBS bucket %s: %s", bucket, err)
+		}
+		return nil
+	}
+
+	var encryption obsBucketEncryption
+	encryption.Rule.Default.SSEAlgorithm = "kms"
+	encryption.Rule.Default.KMSMasterKeyID = d.Get("kms_key_id").(string)
+	_, err := obsClient.DoXML(&obsRequest{
+		Method: "PUT",
+		Bucket: bucket,
+		Query:  obsSubResource("encryption"),
+	}, &encryption, nil)
+	if err != nil {
+		return fmt.Errorf("Error enabling encryption of OBS bucket %s: %s", bucket, err)
+	}
+	return nil
+}
+
+// deleteAllObsObjectVersions deletes every object version and delete marker
+// of bucket, which must be empty before it can be deleted.
+func deleteAllObsObjectVersions(obsClient *obsClient, bucket string) error {
+	keyMarker, versionMarker := "", ""
+	for {
+		query := obsSubResource("versions")
+		if keyMarker != "" {
+			query.Set("key-marker", keyMarker)
+			query.Set("version-id-marker", versionMarker)
+		}
+
+		var result obsListVersionsResult
+		_, err := obsClient.DoXML(&obsRequest{Method: "GET", Bucket: bucket, Query: query}, nil, &result)
+		if err != nil {
+			return fmt.Errorf("Error listing object versions of OBS bucket %s: %s", bucket, err)
+		}
+
+		for _, v := range append(result.Versions, result.DeleteMarkers...) {
+			var query url.Values
+			if v.VersionID != "" && v.VersionID != "null" {
+				query = url.Values{"versionId": []string{v.VersionID}}
+			}
+			_, err := obsClient.Do(&obsRequest{Method: "DELETE", Bucket: bucket, Key: v.Key, Query: query})
+			if err != nil && !isOBSNotFound(err) {
+				return fmt.Errorf("Error deleting object %s of OBS bucket %s: %s",
+					strings.TrimSpace(v.Key+" "+v.VersionID), bucket, err)
+			}
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		keyMarker, versionMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+}
@@ -0,0 +1,174 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccObsBucket_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "huaweicloud_obs_bucket.bucket"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccObsBucket_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bucket", testAccObsBucketName(rInt)),
+					resource.TestCheckResourceAttr(resourceName, "storage_class", "STANDARD"),
+					resource.TestCheckResourceAttr(resourceName, "quota", "0"),
+					resource.TestCheckResourceAttr(resourceName, "encryption", "false"),
+					resource.TestCheckResourceAttr(resourceName, "region", OS_REGION_NAME),
+				),
+			},
+			resource.TestStep{
+				Config: testAccObsBucket_update(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "storage_class", "WARM"),
+					resource.TestCheckResourceAttr(resourceName, "acl", "public-read"),
+					resource.TestCheckResourceAttr(resourceName, "quota", "1073741824"),
+					resource.TestCheckResourceAttr(resourceName, "encryption", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObsBucket_multiAZ(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "huaweicloud_obs_bucket.bucket"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccObsBucket_multiAZ(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "true"),
+					resource.TestCheckResourceAttr(resourceName, "storage_class", "COLD"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObsBucket_importBasic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "huaweicloud_obs_bucket.bucket"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckObsBucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccObsBucket_basic(rInt),
+			},
+
+			resource.TestStep{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"acl", "force_destroy"},
+			},
+		},
+	})
+}
+
+func testAccCheckObsBucketDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	obsClient, err := config.obsClient(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud OBS client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_obs_bucket" {
+			continue
+		}
+
+		_, err := obsClient.Do(&obsRequest{Method: "HEAD", Bucket: rs.Primary.ID})
+		if err == nil {
+			return fmt.Errorf("OBS bucket %s still exists", rs.Primary.ID)
+		}
+		if !isOBSNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckObsBucketExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		obsClient, err := config.obsClient(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud OBS client: %s", err)
+		}
+
+		_, err = obsClient.Do(&obsRequest{Method: "HEAD", Bucket: rs.Primary.ID})
+		if err != nil {
+			return fmt.Errorf("OBS bucket error: %s", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccObsBucketName(randInt int) string {
+	return fmt.Sprintf("tf-test-obs-bucket-%d", randInt)
+}
+
+func testAccObsBucket_basic(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "bucket" {
+  bucket        = "tf-test-obs-bucket-%d"
+  force_destroy = true
+}
+`, randInt)
+}
+
+func testAccObsBucket_update(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "bucket" {
+  bucket        = "tf-test-obs-bucket-%d"
+  storage_class = "WARM"
+  acl           = "public-read"
+  quota         = 1073741824
+  encryption    = true
+  force_destroy = true
+}
+`, randInt)
+}
+
+func testAccObsBucket_multiAZ(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "bucket" {
+  bucket        = "tf-test-obs-bucket-%d"
+  storage_class = "COLD"
+  multi_az      = true
+}
+`, randInt)
+}
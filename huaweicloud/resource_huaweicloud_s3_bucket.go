@@ -265,6 +265,108 @@ func resourceS3Bucket() *schema.Resource {
 								},
 							},
 						},
+						"transition": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Set:      transitionHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"date": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateS3BucketLifecycleTimestamp,
+									},
+									"days": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validateS3BucketLifecycleExpirationDays,
+									},
+									"storage_class": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateS3BucketLifecycleStorageClass,
+									},
+								},
+							},
+						},
+						"noncurrent_version_transition": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Set:      transitionHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"days": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validateS3BucketLifecycleExpirationDays,
+									},
+									"storage_class": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateS3BucketLifecycleStorageClass,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"replication_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"agency": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"rules": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Set:      rulesHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: validateS3BucketReplicationRuleId,
+									},
+									"destination": {
+										Type:     schema.TypeSet,
+										MaxItems: 1,
+										MinItems: 1,
+										Required: true,
+										Set:      destinationHash,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"bucket": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"storage_class": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validateS3BucketReplicationStorageClass,
+												},
+											},
+										},
+									},
+									"prefix": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateS3BucketReplicationRulePrefix,
+									},
+									"status": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateS3BucketReplicationRuleStatus,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -318,6 +420,14 @@ func resourceS3BucketCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error validating S3 bucket name: %s", err)
 	}
 
+	if err := validateS3BucketLifecycleRules(d); err != nil {
+		return err
+	}
+
+	if err := validateS3BucketReplication(d); err != nil {
+		return err
+	}
+
 	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
 		log.Printf("[DEBUG] Trying to create new S3 bucket: %q", bucket)
 		ret, err := s3conn.CreateBucket(req)
@@ -399,6 +509,12 @@ func resourceS3BucketUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChange("replication_configuration") {
+		if err := resourceS3BucketReplicationConfigurationUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
+
 	return resourceS3BucketRead(d, meta)
 }
 
@@ -703,7 +819,7 @@ func resourceS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 						t["days"] = int(*v.Days)
 					}
 					if v.StorageClass != nil {
-						t["storage_class"] = *v.StorageClass
+						t["storage_class"] = obsStorageClass(*v.StorageClass)
 					}
 					transitions = append(transitions, t)
 				}
@@ -718,7 +834,7 @@ func resourceS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 						t["days"] = int(*v.NoncurrentDays)
 					}
 					if v.StorageClass != nil {
-						t["storage_class"] = *v.StorageClass
+						t["storage_class"] = obsStorageClass(*v.StorageClass)
 					}
 					transitions = append(transitions, t)
 				}
@@ -733,6 +849,26 @@ func resourceS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	// Read the bucket replication configuration
+	replicationResponse, err := retryOnAwsCode("NoSuchBucket", func() (interface{}, error) {
+		return s3conn.GetBucketReplication(&s3.GetBucketReplicationInput{
+			Bucket: aws.String(d.Id()),
+		})
+	})
+	if err != nil {
+		if awsError, ok := err.(awserr.RequestFailure); !ok || awsError.StatusCode() != 404 {
+			return err
+		}
+	}
+	var replication *s3.ReplicationConfiguration
+	if replicationResponse != nil {
+		replication = replicationResponse.(*s3.GetBucketReplicationOutput).ReplicationConfiguration
+	}
+	log.Printf("[DEBUG] S3 Bucket: %s, read replication configuration: %v", d.Id(), replication)
+	if err := d.Set("replication_configuration", flattenS3BucketReplicationConfiguration(replication)); err != nil {
+		return fmt.Errorf("Error setting replication_configuration: %s", err)
+	}
+
 	// Add the region as an attribute
 
 	locationResponse, err := retryOnAwsCode("NoSuchBucket", func() (interface{}, error) {
@@ -1245,6 +1381,10 @@ func resourceAwsS3BucketLifecycleUpdate(s3conn *s3.S3, d *schema.ResourceData) e
 		return nil
 	}
 
+	if err := validateS3BucketLifecycleRules(d); err != nil {
+		return err
+	}
+
 	rules := make([]*s3.LifecycleRule, 0, len(lifecycleRules))
 
 	for i, lifecycleRule := range lifecycleRules {
@@ -1308,6 +1448,36 @@ func resourceAwsS3BucketLifecycleUpdate(s3conn *s3.S3, d *schema.ResourceData) e
 			}
 		}
 
+		// Transitions
+		transitions := d.Get(fmt.Sprintf("lifecycle_rule.%d.transition", i)).(*schema.Set).List()
+		for _, transition := range transitions {
+			t := transition.(map[string]interface{})
+			i := &s3.Transition{
+				StorageClass: aws.String(s3StorageClass(t["storage_class"].(string))),
+			}
+
+			if val, ok := t["date"].(string); ok && val != "" {
+				date, err := time.Parse(time.RFC3339, fmt.Sprintf("%sT00:00:00Z", val))
+				if err != nil {
+					return fmt.Errorf("Error Parsing Swift S3 Bucket Lifecycle Transition Date: %s", err.Error())
+				}
+				i.Date = aws.Time(date)
+			} else if val, ok := t["days"].(int); ok && val > 0 {
+				i.Days = aws.Int64(int64(val))
+			}
+			rule.Transitions = append(rule.Transitions, i)
+		}
+
+		// NoncurrentVersionTransitions
+		nc_transitions := d.Get(fmt.Sprintf("lifecycle_rule.%d.noncurrent_version_transition", i)).(*schema.Set).List()
+		for _, transition := range nc_transitions {
+			t := transition.(map[string]interface{})
+			rule.NoncurrentVersionTransitions = append(rule.NoncurrentVersionTransitions, &s3.NoncurrentVersionTransition{
+				NoncurrentDays: aws.Int64(int64(t["days"].(int))),
+				StorageClass:   aws.String(s3StorageClass(t["storage_class"].(string))),
+			})
+		}
+
 		rules = append(rules, rule)
 	}
 
@@ -1333,6 +1503,147 @@ func resourceAwsS3BucketLifecycleUpdate(s3conn *s3.S3, d *schema.ResourceData) e
 	return nil
 }
 
+func resourceS3BucketReplicationConfigurationUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	replicationConfiguration := d.Get("replication_configuration").([]interface{})
+
+	if len(replicationConfiguration) == 0 {
+		i := &s3.DeleteBucketReplicationInput{
+			Bucket: aws.String(bucket),
+		}
+
+		err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+			if _, err := s3conn.DeleteBucketReplication(i); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Error removing S3 bucket replication: %s", err)
+		}
+		return nil
+	}
+
+	if err := validateS3BucketReplication(d); err != nil {
+		return err
+	}
+
+	c := replicationConfiguration[0].(map[string]interface{})
+	rc := &s3.ReplicationConfiguration{
+		Role: aws.String(c["agency"].(string)),
+	}
+
+	for _, v := range c["rules"].(*schema.Set).List() {
+		rr := v.(map[string]interface{})
+		rule := &s3.ReplicationRule{
+			Prefix: aws.String(rr["prefix"].(string)),
+			Status: aws.String(rr["status"].(string)),
+		}
+		if id, ok := rr["id"].(string); ok && id != "" {
+			rule.ID = aws.String(id)
+		}
+
+		dest := rr["destination"].(*schema.Set).List()[0].(map[string]interface{})
+		rule.Destination = &s3.Destination{
+			Bucket: aws.String(s3BucketArn(dest["bucket"].(string))),
+		}
+		if class, ok := dest["storage_class"].(string); ok && class != "" {
+			rule.Destination.StorageClass = aws.String(s3StorageClass(class))
+		}
+
+		rc.Rules = append(rc.Rules, rule)
+	}
+
+	i := &s3.PutBucketReplicationInput{
+		Bucket:                   aws.String(bucket),
+		ReplicationConfiguration: rc,
+	}
+	log.Printf("[DEBUG] S3 put bucket replication configuration: %#v", i)
+
+	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+		if _, err := s3conn.PutBucketReplication(i); err != nil {
+			// A newly created agency takes a moment to propagate.
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidArgument" {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 replication configuration: %s", err)
+	}
+
+	return nil
+}
+
+// validateS3BucketReplication checks that versioning is enabled whenever a
+// replication configuration is set, OBS only replicates versioned buckets.
+func validateS3BucketReplication(d *schema.ResourceData) error {
+	if len(d.Get("replication_configuration").([]interface{})) == 0 {
+		return nil
+	}
+
+	versioning := d.Get("versioning").([]interface{})
+	if len(versioning) > 0 && versioning[0] != nil {
+		if v, ok := versioning[0].(map[string]interface{})["enabled"].(bool); ok && v {
+			return nil
+		}
+	}
+	return fmt.Errorf("Error validating replication_configuration: versioning must be enabled on bucket %s", d.Get("bucket").(string))
+}
+
+func flattenS3BucketReplicationConfiguration(r *s3.ReplicationConfiguration) []map[string]interface{} {
+	if r == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := make(map[string]interface{})
+	if r.Role != nil && *r.Role != "" {
+		m["agency"] = *r.Role
+	}
+
+	rules := make([]interface{}, 0, len(r.Rules))
+	for _, v := range r.Rules {
+		t := make(map[string]interface{})
+		if v.Destination != nil {
+			rd := make(map[string]interface{})
+			if v.Destination.Bucket != nil {
+				rd["bucket"] = strings.TrimPrefix(*v.Destination.Bucket, s3BucketArnPrefix)
+			}
+			if v.Destination.StorageClass != nil {
+				rd["storage_class"] = obsStorageClass(*v.Destination.StorageClass)
+			}
+			t["destination"] = schema.NewSet(destinationHash, []interface{}{rd})
+		}
+
+		if v.ID != nil {
+			t["id"] = *v.ID
+		}
+		if v.Prefix != nil {
+			t["prefix"] = *v.Prefix
+		}
+		if v.Status != nil {
+			t["status"] = *v.Status
+		}
+		rules = append(rules, t)
+	}
+	m["rules"] = schema.NewSet(rulesHash, rules)
+
+	return []map[string]interface{}{m}
+}
+
+const s3BucketArnPrefix = "arn:aws:s3:::"
+
+// s3BucketArn returns the ARN the S3 API identifies bucket by in replication
+// destinations.
+func s3BucketArn(bucket string) string {
+	if strings.HasPrefix(bucket, s3BucketArnPrefix) {
+		return bucket
+	}
+	return s3BucketArnPrefix + bucket
+}
+
 func normalizeRoutingRules(w []*s3.RoutingRule) (string, error) {
 	withNulls, err := json.Marshal(w)
 	if err != nil {
@@ -1434,6 +1745,156 @@ func validateS3BucketName(value string, region string) error {
 	return nil
 }
 
+// s3StorageClass maps the OBS storage classes of lifecycle transitions to
+// their S3 names, OBS translates them back on its side.
+func s3StorageClass(class string) string {
+	switch class {
+	case "WARM":
+		return s3.TransitionStorageClassStandardIa
+	case "COLD":
+		return s3.TransitionStorageClassGlacier
+	}
+	return class
+}
+
+func obsStorageClass(class string) string {
+	switch class {
+	case s3.TransitionStorageClassStandardIa:
+		return "WARM"
+	case s3.TransitionStorageClassGlacier:
+		return "COLD"
+	}
+	return class
+}
+
+// lifecycleAction is the point in time, either a number of days or a date,
+// at which a lifecycle rule transitions or expires objects.
+type lifecycleAction struct {
+	name string
+	days int
+	date string
+}
+
+func newLifecycleAction(name string, m map[string]interface{}) lifecycleAction {
+	a := lifecycleAction{name: name}
+	if v, ok := m["days"].(int); ok {
+		a.days = v
+	}
+	if v, ok := m["date"].(string); ok {
+		a.date = v
+	}
+	return a
+}
+
+// validateS3BucketLifecycleRules checks the transitions of every lifecycle
+// rule of d. It is called before the rules are sent, the schema alone can
+// not express the ordering constraints between the blocks.
+func validateS3BucketLifecycleRules(d *schema.ResourceData) error {
+	for i, v := range d.Get("lifecycle_rule").([]interface{}) {
+		rule, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := validateS3BucketLifecycleRule(rule); err != nil {
+			return fmt.Errorf("Error validating lifecycle_rule.%d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// validateS3BucketLifecycleRule checks that objects go through WARM before
+// COLD and that they expire after their last transition, for both current
+// and noncurrent versions.
+func validateS3BucketLifecycleRule(rule map[string]interface{}) error {
+	current, err := lifecycleTransitions(rule, "transition")
+	if err != nil {
+		return err
+	}
+	var expiration []lifecycleAction
+	if v, ok := rule["expiration"].(*schema.Set); ok {
+		for _, e := range v.List() {
+			a := newLifecycleAction("expiration", e.(map[string]interface{}))
+			if a.days > 0 || a.date != "" {
+				expiration = append(expiration, a)
+			}
+		}
+	}
+	for _, t := range current {
+		if t.days > 0 && t.date != "" {
+			return fmt.Errorf("%s: only one of date or days can be set", t.name)
+		}
+		if t.days == 0 && t.date == "" {
+			return fmt.Errorf("%s: one of date or days must be set", t.name)
+		}
+	}
+	if err := validateLifecycleOrder(current, expiration); err != nil {
+		return err
+	}
+
+	noncurrent, err := lifecycleTransitions(rule, "noncurrent_version_transition")
+	if err != nil {
+		return err
+	}
+	var noncurrentExpiration []lifecycleAction
+	if v, ok := rule["noncurrent_version_expiration"].(*schema.Set); ok {
+		for _, e := range v.List() {
+			a := newLifecycleAction("noncurrent_version_expiration", e.(map[string]interface{}))
+			if a.days > 0 {
+				noncurrentExpiration = append(noncurrentExpiration, a)
+			}
+		}
+	}
+	return validateLifecycleOrder(noncurrent, noncurrentExpiration)
+}
+
+// lifecycleTransitions returns the transitions of the given block of rule
+// ordered WARM then COLD.
+func lifecycleTransitions(rule map[string]interface{}, key string) ([]lifecycleAction, error) {
+	set, ok := rule[key].(*schema.Set)
+	if !ok {
+		return nil, nil
+	}
+
+	byClass := make(map[string]lifecycleAction)
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+		class := m["storage_class"].(string)
+		if _, ok := byClass[class]; ok {
+			return nil, fmt.Errorf("only one %s to %s is allowed", key, class)
+		}
+		byClass[class] = newLifecycleAction(fmt.Sprintf("%s to %s", key, class), m)
+	}
+
+	var transitions []lifecycleAction
+	for _, class := range []string{"WARM", "COLD"} {
+		if t, ok := byClass[class]; ok {
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions, nil
+}
+
+// validateLifecycleOrder checks that each of the transitions happens
+// strictly after the previous one and before the expiration.
+func validateLifecycleOrder(transitions, expiration []lifecycleAction) error {
+	actions := append(transitions, expiration...)
+	for i := 1; i < len(actions); i++ {
+		prev, next := actions[i-1], actions[i]
+		if (prev.date != "") != (next.date != "") {
+			return fmt.Errorf("%s and %s must both use either days or date", prev.name, next.name)
+		}
+		if prev.date != "" {
+			// Dates are validated as YYYY-MM-DD, they sort as strings.
+			if next.date <= prev.date {
+				return fmt.Errorf("%s (%s) must be after %s (%s)", next.name, next.date, prev.name, prev.date)
+			}
+		} else if next.days <= prev.days {
+			return fmt.Errorf("%s (%d days) must be after %s (%d days)", next.name, next.days, prev.name, prev.days)
+		}
+	}
+	return nil
+}
+
 func expirationHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
@@ -1464,13 +1925,13 @@ func transitionHash(v interface{}) int {
 	return hashcode.String(buf.String())
 }
 
+// rulesHash identifies a replication rule by its prefix, which OBS requires
+// to be unique among the rules. The id is left out as OBS generates one when
+// it is omitted, a changed id is still picked up as a change of the rule.
 func rulesHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
 
-	if v, ok := m["id"]; ok {
-		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
-	}
 	if v, ok := m["prefix"]; ok {
 		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
 	}
@@ -0,0 +1,228 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceS3BucketNotification() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceS3BucketNotificationPut,
+		Read:   resourceS3BucketNotificationRead,
+		Update: resourceS3BucketNotificationPut,
+		Delete: resourceS3BucketNotificationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"topic": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"topic_urn": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"events": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"filter_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"filter_suffix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceS3BucketNotificationPut(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	s3conn, err := config.computeS3conn(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+
+	topics := d.Get("topic").([]interface{})
+	topicConfigs := make([]*s3.TopicConfiguration, 0, len(topics))
+	for _, t := range topics {
+		c := t.(map[string]interface{})
+
+		id := c["id"].(string)
+		if id == "" {
+			id = resource.PrefixedUniqueId("tf-s3-topic-")
+		}
+
+		events := make([]*string, 0)
+		for _, e := range c["events"].(*schema.Set).List() {
+			events = append(events, aws.String(e.(string)))
+		}
+
+		topicConfigs = append(topicConfigs, &s3.TopicConfiguration{
+			Id:       aws.String(id),
+			TopicArn: aws.String(c["topic_urn"].(string)),
+			Events:   events,
+			Filter:   expandS3NotificationFilter(c["filter_prefix"].(string), c["filter_suffix"].(string)),
+		})
+	}
+
+	params := &s3.PutBucketNotificationConfigurationInput{
+		Bucket: aws.String(bucket),
+		NotificationConfiguration: &s3.NotificationConfiguration{
+			TopicConfigurations: topicConfigs,
+		},
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, put notification: %#v", bucket, params)
+	_, err = retryOnAwsCode("NoSuchBucket", func() (interface{}, error) {
+		return s3conn.PutBucketNotificationConfiguration(params)
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 notification configuration: %s", err)
+	}
+
+	d.SetId(bucket)
+
+	return resourceS3BucketNotificationRead(d, meta)
+}
+
+func resourceS3BucketNotificationRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	s3conn, err := config.computeS3conn(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+	}
+
+	log.Printf("[DEBUG] S3 bucket notification, read for bucket: %s", d.Id())
+	notification, err := s3conn.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+		Bucket: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchBucket" {
+			log.Printf("[WARN] S3 Bucket (%s) not found, removing notification from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading S3 bucket notification configuration: %s", err)
+	}
+
+	d.Set("bucket", d.Id())
+	d.Set("region", GetRegion(d, config))
+	if err := d.Set("topic", flattenS3TopicConfigurations(notification.TopicConfigurations)); err != nil {
+		return fmt.Errorf("Error setting topic: %s", err)
+	}
+
+	return nil
+}
+
+func resourceS3BucketNotificationDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	s3conn, err := config.computeS3conn(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+
+	log.Printf("[DEBUG] S3 bucket: %s, delete notification", bucket)
+	_, err = s3conn.PutBucketNotificationConfiguration(&s3.PutBucketNotificationConfigurationInput{
+		Bucket:                    aws.String(bucket),
+		NotificationConfiguration: &s3.NotificationConfiguration{},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchBucket" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting S3 notification configuration: %s", err)
+	}
+
+	return nil
+}
+
+func expandS3NotificationFilter(prefix, suffix string) *s3.NotificationConfigurationFilter {
+	rules := make([]*s3.FilterRule, 0, 2)
+	if prefix != "" {
+		rules = append(rules, &s3.FilterRule{
+			Name:  aws.String(s3.FilterRuleNamePrefix),
+			Value: aws.String(prefix),
+		})
+	}
+	if suffix != "" {
+		rules = append(rules, &s3.FilterRule{
+			Name:  aws.String(s3.FilterRuleNameSuffix),
+			Value: aws.String(suffix),
+		})
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return &s3.NotificationConfigurationFilter{
+		Key: &s3.KeyFilter{
+			FilterRules: rules,
+		},
+	}
+}
+
+func flattenS3TopicConfigurations(configs []*s3.TopicConfiguration) []map[string]interface{} {
+	topics := make([]map[string]interface{}, 0, len(configs))
+	for _, c := range configs {
+		t := map[string]interface{}{
+			"id":        aws.StringValue(c.Id),
+			"topic_urn": aws.StringValue(c.TopicArn),
+			"events":    schema.NewSet(schema.HashString, flattenStringList(c.Events)),
+		}
+
+		if c.Filter != nil && c.Filter.Key != nil {
+			for _, r := range c.Filter.Key.FilterRules {
+				// The rule names come back capitalized (Prefix, Suffix).
+				switch strings.ToLower(aws.StringValue(r.Name)) {
+				case s3.FilterRuleNamePrefix:
+					t["filter_prefix"] = aws.StringValue(r.Value)
+				case s3.FilterRuleNameSuffix:
+					t["filter_suffix"] = aws.StringValue(r.Value)
+				}
+			}
+		}
+
+		topics = append(topics, t)
+	}
+	return topics
+}
@@ -0,0 +1,145 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccS3BucketNotification_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccS3BucketNotification_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketNotificationTopics("huaweicloud_s3_bucket_notification.notification", 1),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_notification.notification", "topic.0.events.#", "1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_notification.notification", "topic.0.filter_prefix", "ingest/"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_notification.notification", "topic.0.filter_suffix", ".log"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccS3BucketNotification_update(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketNotificationTopics("huaweicloud_s3_bucket_notification.notification", 1),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_notification.notification", "topic.0.events.#", "2"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_notification.notification", "topic.0.filter_prefix", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccS3BucketNotification_importBasic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "huaweicloud_s3_bucket_notification.notification"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccS3BucketNotification_basic(rInt),
+			},
+
+			resource.TestStep{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckS3BucketNotificationTopics(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No S3 Bucket ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		conn, err := config.computeS3conn(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+		}
+
+		notification, err := conn.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+			Bucket: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("GetBucketNotificationConfiguration error: %v", err)
+		}
+
+		if len(notification.TopicConfigurations) != count {
+			return fmt.Errorf("Expected %d topic configurations, got %d", count, len(notification.TopicConfigurations))
+		}
+
+		return nil
+	}
+}
+
+func testAccS3BucketNotification_basic(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_smn_topic_v2" "topic" {
+	name = "tf-test-topic-%d"
+}
+
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+}
+
+resource "huaweicloud_s3_bucket_notification" "notification" {
+	bucket = "${huaweicloud_s3_bucket.bucket.id}"
+
+	topic {
+		topic_urn     = "${huaweicloud_smn_topic_v2.topic.topic_urn}"
+		events        = ["ObjectCreated:*"]
+		filter_prefix = "ingest/"
+		filter_suffix = ".log"
+	}
+}
+`, randInt, randInt)
+}
+
+func testAccS3BucketNotification_update(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_smn_topic_v2" "topic" {
+	name = "tf-test-topic-%d"
+}
+
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+}
+
+resource "huaweicloud_s3_bucket_notification" "notification" {
+	bucket = "${huaweicloud_s3_bucket.bucket.id}"
+
+	topic {
+		topic_urn     = "${huaweicloud_smn_topic_v2.topic.topic_urn}"
+		events        = ["ObjectCreated:*", "ObjectRemoved:*"]
+		filter_suffix = ".log"
+	}
+}
+`, randInt, randInt)
+}
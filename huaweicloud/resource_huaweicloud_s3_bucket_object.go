@@ -21,7 +21,7 @@ func resourceS3BucketObject() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceS3BucketObjectPut,
 		Read:   resourceS3BucketObjectRead,
-		Update: resourceS3BucketObjectPut,
+		Update: resourceS3BucketObjectUpdate,
 		Delete: resourceS3BucketObjectDelete,
 
 		Schema: map[string]*schema.Schema{
@@ -97,13 +97,51 @@ func resourceS3BucketObject() *schema.Resource {
 
 			"etag": {
 				Type: schema.TypeString,
-				// This will conflict with SSE-C and SSE-KMS encryption and multi-part upload
-				// if/when it's actually implemented. The Etag then won't match raw-file MD5.
+				// This will conflict with SSE-C and SSE-KMS encryption. The Etag then won't
+				// match raw-file MD5. The Etag of multipart uploads is mapped back to the
+				// MD5 of source when it matches, see resourceS3BucketObjectRead.
 				// See http://docs.aws.amazon.com/AmazonS3/latest/API/RESTCommonResponseHeaders.html
 				Optional: true,
 				Computed: true,
 			},
 
+			"multipart_threshold": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  64,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateIntRange(v, k, 5, 5*1024*1024)
+				},
+			},
+
+			"multipart_part_size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  16,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateIntRange(v, k, 5, 5*1024)
+				},
+			},
+
+			"multipart_concurrency": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  4,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateIntRange(v, k, 1, 32)
+				},
+			},
+
+			"multipart_upload_part_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"multipart_etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"version_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -125,17 +163,16 @@ func resourceS3BucketObjectPut(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	var body io.ReadSeeker
+	var file *os.File
+	var size int64
 
 	if v, ok := d.GetOk("source"); ok {
 		source := v.(string)
-		path, err := homedir.Expand(source)
-		if err != nil {
-			return fmt.Errorf("Error expanding homedir in source (%s): %s", source, err)
-		}
-		file, err := os.Open(path)
+		file, size, err = openS3BucketObjectSource(source)
 		if err != nil {
-			return fmt.Errorf("Error opening S3 bucket object source (%s): %s", source, err)
+			return err
 		}
+		defer file.Close()
 
 		body = file
 	} else if v, ok := d.GetOk("content"); ok {
@@ -187,6 +224,28 @@ func resourceS3BucketObjectPut(d *schema.ResourceData, meta interface{}) error {
 		putInput.WebsiteRedirectLocation = aws.String(v.(string))
 	}
 
+	if file != nil && size >= int64(d.Get("multipart_threshold").(int))*1024*1024 {
+		upload := &s3MultipartUpload{
+			Conn:        s3conn,
+			Input:       s3CreateMultipartUploadInput(putInput),
+			Body:        file,
+			Size:        size,
+			PartSize:    s3MultipartPartSize(size, int64(d.Get("multipart_part_size").(int))*1024*1024),
+			Concurrency: d.Get("multipart_concurrency").(int),
+		}
+		resp, err := upload.Upload()
+		if err != nil {
+			return fmt.Errorf("Error putting object in S3 bucket (%s): %s", bucket, err)
+		}
+
+		// The etag is left to resourceS3BucketObjectRead, which maps the
+		// multipart ETag back to the MD5 of source with the part size used.
+		d.Set("multipart_upload_part_size", upload.PartSize)
+		d.Set("version_id", resp.VersionId)
+		d.SetId(key)
+		return resourceS3BucketObjectRead(d, meta)
+	}
+
 	resp, err := s3conn.PutObject(putInput)
 	if err != nil {
 		return fmt.Errorf("Error putting object in S3 bucket (%s): %s", bucket, err)
@@ -194,12 +253,92 @@ func resourceS3BucketObjectPut(d *schema.ResourceData, meta interface{}) error {
 
 	// See https://forums.aws.amazon.com/thread.jspa?threadID=44003
 	d.Set("etag", strings.Trim(*resp.ETag, `"`))
+	d.Set("multipart_upload_part_size", 0)
 
 	d.Set("version_id", resp.VersionId)
 	d.SetId(key)
 	return resourceS3BucketObjectRead(d, meta)
 }
 
+func openS3BucketObjectSource(source string) (*os.File, int64, error) {
+	path, err := homedir.Expand(source)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Error expanding homedir in source (%s): %s", source, err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Error opening S3 bucket object source (%s): %s", source, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("Error reading S3 bucket object source (%s): %s", source, err)
+	}
+	return file, info.Size(), nil
+}
+
+// s3BucketObjectTuningArgs only tune how source is uploaded, they don't
+// change the object.
+var s3BucketObjectTuningArgs = map[string]bool{
+	"multipart_threshold":   true,
+	"multipart_part_size":   true,
+	"multipart_concurrency": true,
+}
+
+// resourceS3BucketObjectUpdate uploads the object again, unless only the
+// tuning arguments changed: their new values are used by the next upload.
+func resourceS3BucketObjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	for k, s := range resourceS3BucketObject().Schema {
+		if s3BucketObjectTuningArgs[k] || (!s.Optional && !s.Required) {
+			continue
+		}
+		if d.HasChange(k) {
+			return resourceS3BucketObjectPut(d, meta)
+		}
+	}
+
+	log.Printf("[DEBUG] Only the upload settings of S3 bucket object %s changed", d.Id())
+	return resourceS3BucketObjectRead(d, meta)
+}
+
+// s3BucketObjectETag returns the etag to record for an object: its ETag,
+// unless it is the multipart ETag of the content of source, in which case
+// the MD5 of source is returned so that etag = "${md5(file(...))}" stays
+// stable. Once mapped, source is not hashed again while the ETag of the
+// object is unchanged.
+func s3BucketObjectETag(d *schema.ResourceData, etag string) string {
+	source, ok := d.GetOk("source")
+	if !ok || !isS3MultipartETag(etag) || etag == d.Get("etag").(string) {
+		return etag
+	}
+	if current := d.Get("etag").(string); current != "" && etag == d.Get("multipart_etag").(string) {
+		return current
+	}
+
+	file, size, err := openS3BucketObjectSource(source.(string))
+	if err != nil {
+		log.Printf("[WARN] Unable to compare the ETag of %s with its source: %s", d.Id(), err)
+		return etag
+	}
+	defer file.Close()
+
+	// The part size of objects uploaded before it was recorded is derived
+	// from the configuration.
+	partSize := int64(d.Get("multipart_upload_part_size").(int))
+	if partSize == 0 {
+		partSize = s3MultipartPartSize(size, int64(d.Get("multipart_part_size").(int))*1024*1024)
+	}
+	sum, multipartETag, err := s3ObjectETags(file, size, partSize)
+	if err != nil {
+		log.Printf("[WARN] Unable to compare the ETag of %s with its source: %s", d.Id(), err)
+		return etag
+	}
+	if multipartETag != etag {
+		return etag
+	}
+	return sum
+}
+
 func resourceS3BucketObjectRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	s3conn, err := config.computeS3conn(GetRegion(d, config))
@@ -236,7 +375,13 @@ func resourceS3BucketObjectRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("server_side_encryption", resp.ServerSideEncryption)
 	d.Set("website_redirect", resp.WebsiteRedirectLocation)
 
-	d.Set("etag", strings.Trim(*resp.ETag, `"`))
+	etag := strings.Trim(*resp.ETag, `"`)
+	d.Set("etag", s3BucketObjectETag(d, etag))
+	if isS3MultipartETag(etag) {
+		d.Set("multipart_etag", etag)
+	} else {
+		d.Set("multipart_etag", "")
+	}
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package huaweicloud
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -44,6 +45,51 @@ func TestAccS3BucketObject_source(t *testing.T) {
 	})
 }
 
+func TestAccS3BucketObject_multipart(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "tf-acc-s3-obj-multipart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	rInt := acctest.RandInt()
+	// 12 MiB are uploaded in 3 parts of 5 MiB.
+	err = ioutil.WriteFile(tmpFile.Name(), bytes.Repeat([]byte("0123456789abcdef"), 12*1024*1024/16), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obj s3.GetObjectOutput
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccS3BucketObjectConfigMultipart(rInt, tmpFile.Name(), 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketObjectExists("huaweicloud_s3_bucket_object.object", &obj),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_object.object", "etag", "6114474bb68ffaf28d2827d74f1339cf"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_object.object", "multipart_upload_part_size", "5242880"),
+				),
+			},
+			// Changing the part size alone does not upload the object again.
+			resource.TestStep{
+				Config: testAccS3BucketObjectConfigMultipart(rInt, tmpFile.Name(), 6),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketObjectExists("huaweicloud_s3_bucket_object.object", &obj),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_object.object", "etag", "6114474bb68ffaf28d2827d74f1339cf"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_object.object", "multipart_upload_part_size", "5242880"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccS3BucketObject_content(t *testing.T) {
 	rInt := acctest.RandInt()
 	var obj s3.GetObjectOutput
@@ -472,6 +518,22 @@ resource "huaweicloud_s3_bucket_object" "object" {
 `, randInt, source)
 }
 
+func testAccS3BucketObjectConfigMultipart(randInt int, source string, partSize int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "object_bucket" {
+    bucket = "tf-object-test-bucket-%d"
+}
+resource "huaweicloud_s3_bucket_object" "object" {
+	bucket = "${huaweicloud_s3_bucket.object_bucket.bucket}"
+	key = "test-key"
+	source = "%s"
+	etag = "${md5(file("%s"))}"
+	multipart_threshold = 5
+	multipart_part_size = %d
+}
+`, randInt, source, source, partSize)
+}
+
 func testAccS3BucketObjectConfig_withContentCharacteristics(randInt int, source string) string {
 	return fmt.Sprintf(`
 resource "huaweicloud_s3_bucket" "object_bucket_2" {
@@ -0,0 +1,574 @@
+package huaweicloud
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/mitchellh/go-homedir"
+)
+
+func resourceS3BucketObjectsSync() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceS3BucketObjectsSyncPut,
+		Read:   resourceS3BucketObjectsSyncRead,
+		Update: resourceS3BucketObjectsSyncPut,
+		Delete: resourceS3BucketObjectsSyncDelete,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_dir": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"acl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "private",
+				ValidateFunc: validateS3BucketObjectAclType,
+			},
+
+			"cache_control": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pattern": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"acl": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateS3BucketObjectAclType,
+						},
+						"cache_control": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"content_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"delete_stale": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"concurrency": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  4,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateIntRange(v, k, 1, 32)
+				},
+			},
+
+			"files": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+
+			// The keys which are out of sync are recorded on refresh, so
+			// that the plan lists them as changes towards the empty lists of
+			// the configuration.
+			"pending_uploads": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateUnset,
+				},
+			},
+
+			"pending_deletes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateUnset,
+				},
+			},
+		},
+	}
+}
+
+// s3SyncFile is a file of the source directory and the settings it is
+// uploaded with.
+type s3SyncFile struct {
+	Path         string
+	MD5          string
+	ACL          string
+	CacheControl string
+	ContentType  string
+}
+
+// Fingerprint identifies the content and the settings of the file, it
+// changes whenever the object has to be uploaded again.
+func (f *s3SyncFile) Fingerprint() string {
+	settings := strings.Join([]string{f.ACL, f.CacheControl, f.ContentType}, "|")
+	return fmt.Sprintf("%s-%d", f.MD5, hashcode.String(settings))
+}
+
+// s3SyncRule overrides the settings of the files matching Pattern.
+type s3SyncRule struct {
+	Pattern      string
+	ACL          string
+	CacheControl string
+	ContentType  string
+}
+
+// Match reports whether the relative path rel matches the rule. Patterns
+// without a slash are matched against the base name of the file, so that
+// "*.html" matches the HTML files of every directory.
+func (r *s3SyncRule) Match(rel string) bool {
+	name := rel
+	if !strings.Contains(r.Pattern, "/") {
+		name = path.Base(rel)
+	}
+	ok, _ := path.Match(r.Pattern, name)
+	return ok
+}
+
+func expandS3SyncRules(d *schema.ResourceData) ([]s3SyncRule, error) {
+	var rules []s3SyncRule
+	for _, v := range d.Get("rule").([]interface{}) {
+		r := v.(map[string]interface{})
+		rule := s3SyncRule{
+			Pattern:      r["pattern"].(string),
+			ACL:          r["acl"].(string),
+			CacheControl: r["cache_control"].(string),
+			ContentType:  r["content_type"].(string),
+		}
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("Invalid rule pattern %q: %s", rule.Pattern, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// s3SyncKeyPrefix returns prefix as the directory the files are synced to.
+func s3SyncKeyPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// walkS3SyncSource returns the files of dir by object key. The settings of
+// a file are taken from the first rule matching it, falling back to acl and
+// cacheControl, and to the content type detected from its extension or its
+// content.
+func walkS3SyncSource(dir, prefix, acl, cacheControl string, rules []s3SyncRule) (map[string]*s3SyncFile, error) {
+	files := make(map[string]*s3SyncFile)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		f := &s3SyncFile{
+			Path:         p,
+			ACL:          acl,
+			CacheControl: cacheControl,
+		}
+		for _, r := range rules {
+			if !r.Match(rel) {
+				continue
+			}
+			if r.ACL != "" {
+				f.ACL = r.ACL
+			}
+			if r.CacheControl != "" {
+				f.CacheControl = r.CacheControl
+			}
+			f.ContentType = r.ContentType
+			break
+		}
+
+		if err := f.inspect(); err != nil {
+			return err
+		}
+		files[prefix+rel] = f
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source_dir %s: %s", dir, err)
+	}
+	return files, nil
+}
+
+// inspect computes the MD5 of the file and detects its content type unless
+// it is set already.
+func (f *s3SyncFile) inspect() error {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	h := md5.New()
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	h.Write(head[:n])
+	if _, err := io.Copy(h, file); err != nil {
+		return err
+	}
+	f.MD5 = hex.EncodeToString(h.Sum(nil))
+
+	if f.ContentType == "" {
+		f.ContentType = mime.TypeByExtension(path.Ext(f.Path))
+	}
+	if f.ContentType == "" {
+		f.ContentType = http.DetectContentType(head[:n])
+	}
+	return nil
+}
+
+// s3SyncRecord is what the files attribute records of a synced key: the
+// fingerprint of the file and the ETag of the object it was uploaded to. The
+// ETag is not the MD5 of the content for encrypted objects, so the content is
+// compared through the fingerprint, and the ETag only tells whether the
+// object was replaced since.
+func s3SyncRecord(fingerprint, etag string) string {
+	return fingerprint + "@" + etag
+}
+
+// s3SyncPlan returns the keys to upload, the local files which are missing
+// remotely, which changed or whose settings changed since they were synced,
+// or whose object was replaced since, and the keys to delete, the remote
+// objects without a local file.
+func s3SyncPlan(local map[string]*s3SyncFile, remote, synced map[string]string, deleteStale bool) ([]string, []string) {
+	uploads := []string{}
+	for key, f := range local {
+		etag, ok := remote[key]
+		if !ok || synced[key] != s3SyncRecord(f.Fingerprint(), etag) {
+			uploads = append(uploads, key)
+		}
+	}
+
+	deletes := []string{}
+	if deleteStale {
+		for key := range remote {
+			if _, ok := local[key]; !ok {
+				deletes = append(deletes, key)
+			}
+		}
+	}
+
+	sort.Strings(uploads)
+	sort.Strings(deletes)
+	return uploads, deletes
+}
+
+func listS3SyncObjects(s3conn *s3.S3, bucket, prefix string) (map[string]string, error) {
+	objects := make(map[string]string)
+	err := s3conn.ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, o := range page.Contents {
+			key := aws.StringValue(o.Key)
+			// Skip the directory markers created by the console.
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+			objects[key] = strings.Trim(aws.StringValue(o.ETag), `"`)
+		}
+		return true
+	})
+	return objects, err
+}
+
+func s3SyncSynced(d *schema.ResourceData) map[string]string {
+	synced := make(map[string]string)
+	for k, v := range d.Get("files").(map[string]interface{}) {
+		synced[k] = v.(string)
+	}
+	return synced
+}
+
+func s3SyncSource(d *schema.ResourceData) (map[string]*s3SyncFile, error) {
+	dir, err := homedir.Expand(d.Get("source_dir").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding homedir in source_dir: %s", err)
+	}
+	rules, err := expandS3SyncRules(d)
+	if err != nil {
+		return nil, err
+	}
+	return walkS3SyncSource(dir, s3SyncKeyPrefix(d.Get("prefix").(string)),
+		d.Get("acl").(string), d.Get("cache_control").(string), rules)
+}
+
+func resourceS3BucketObjectsSyncPut(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	s3conn, err := config.computeS3conn(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	prefix := s3SyncKeyPrefix(d.Get("prefix").(string))
+
+	local, err := s3SyncSource(d)
+	if err != nil {
+		return err
+	}
+	remote, err := listS3SyncObjects(s3conn, bucket, prefix)
+	if err != nil {
+		return fmt.Errorf("Error listing objects of S3 bucket (%s): %s", bucket, err)
+	}
+
+	uploads, deletes := s3SyncPlan(local, remote, s3SyncSynced(d), d.Get("delete_stale").(bool))
+	log.Printf("[DEBUG] Syncing %s to %s/%s: %d uploads, %d deletes", d.Get("source_dir").(string), bucket, prefix, len(uploads), len(deletes))
+
+	// Record what was synced even on failure, so that the next run only
+	// retries the keys which failed.
+	synced := s3SyncSynced(d)
+	defer func() {
+		d.Set("files", synced)
+	}()
+	if d.Id() == "" {
+		d.SetId(fmt.Sprintf("%s/%s", bucket, prefix))
+	}
+
+	var mu sync.Mutex
+	err = s3SyncEach(uploads, d.Get("concurrency").(int), func(key string) error {
+		f := local[key]
+		etag, err := putS3SyncObject(s3conn, bucket, key, f)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		synced[key] = s3SyncRecord(f.Fingerprint(), etag)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error uploading objects to S3 bucket (%s): %s", bucket, err)
+	}
+
+	err = s3SyncEach(deletes, d.Get("concurrency").(int), func(key string) error {
+		_, err := s3conn.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		delete(synced, key)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting stale objects of S3 bucket (%s): %s", bucket, err)
+	}
+
+	// Forget the keys removed from the source while delete_stale was off.
+	for key := range synced {
+		if _, ok := local[key]; !ok {
+			delete(synced, key)
+		}
+	}
+	d.Set("pending_uploads", []string{})
+	d.Set("pending_deletes", []string{})
+
+	return resourceS3BucketObjectsSyncRead(d, meta)
+}
+
+// putS3SyncObject uploads the file to key and returns the ETag of the object.
+func putS3SyncObject(s3conn *s3.S3, bucket, key string, f *s3SyncFile) (string, error) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		ACL:         aws.String(f.ACL),
+		ContentType: aws.String(f.ContentType),
+		Body:        file,
+	}
+	if f.CacheControl != "" {
+		input.CacheControl = aws.String(f.CacheControl)
+	}
+
+	log.Printf("[DEBUG] Uploading %s to %s/%s", f.Path, bucket, key)
+	resp, err := s3conn.PutObject(input)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(aws.StringValue(resp.ETag), `"`), nil
+}
+
+// s3SyncEach calls f for each of the keys, concurrency of them at a time,
+// and returns the errors of every key which failed.
+func s3SyncEach(keys []string, concurrency int, f func(key string) error) error {
+	todo := make(chan string, len(keys))
+	for _, k := range keys {
+		todo <- k
+	}
+	close(todo)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []string
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range todo {
+				if err := f(k); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Sprintf("%s: %s", k, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func resourceS3BucketObjectsSyncRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	s3conn, err := config.computeS3conn(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	prefix := s3SyncKeyPrefix(d.Get("prefix").(string))
+
+	remote, err := listS3SyncObjects(s3conn, bucket, prefix)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchBucket" {
+			log.Printf("[WARN] S3 Bucket (%s) not found, removing objects sync from state", bucket)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error listing objects of S3 bucket (%s): %s", bucket, err)
+	}
+
+	// Forget the objects which were removed behind our back.
+	synced := s3SyncSynced(d)
+	for key := range synced {
+		if _, ok := remote[key]; !ok {
+			delete(synced, key)
+		}
+	}
+	d.Set("files", synced)
+	d.Set("region", GetRegion(d, config))
+
+	local, err := s3SyncSource(d)
+	if err != nil {
+		// The source may be gone when the resource is being destroyed.
+		log.Printf("[WARN] Unable to compare %s with its source: %s", d.Id(), err)
+		return nil
+	}
+
+	uploads, deletes := s3SyncPlan(local, remote, synced, d.Get("delete_stale").(bool))
+	if len(uploads) > 0 || len(deletes) > 0 {
+		log.Printf("[DEBUG] %s is out of sync: uploads %v, deletes %v", d.Id(), uploads, deletes)
+	}
+	d.Set("pending_uploads", uploads)
+	d.Set("pending_deletes", deletes)
+
+	return nil
+}
+
+func resourceS3BucketObjectsSyncDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	s3conn, err := config.computeS3conn(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	keys := make([]string, 0)
+	for key := range s3SyncSynced(d) {
+		keys = append(keys, key)
+	}
+
+	err = s3SyncEach(keys, d.Get("concurrency").(int), func(key string) error {
+		_, err := s3conn.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchBucket" {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting objects of S3 bucket (%s): %s", bucket, err)
+	}
+
+	return nil
+}
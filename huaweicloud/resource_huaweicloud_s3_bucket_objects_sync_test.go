@@ -0,0 +1,209 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccS3BucketObjectsSync_basic(t *testing.T) {
+	dir := testAccS3BucketObjectsSyncSource(t, map[string]string{
+		"index.html":    "<html></html>",
+		"css/main.css":  "body {}",
+		"old/stale.txt": "stale",
+	})
+	defer os.RemoveAll(dir)
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccS3BucketObjectsSync_basic(rInt, dir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketObjectsSyncKeys("huaweicloud_s3_bucket_objects_sync.sync",
+						[]string{"site/css/main.css", "site/index.html", "site/old/stale.txt"}),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_objects_sync.sync", "files.%", "3"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_objects_sync.sync", "pending_uploads.#", "0"),
+				),
+			},
+			resource.TestStep{
+				PreConfig: func() {
+					os.RemoveAll(filepath.Join(dir, "old"))
+					ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("<html>v2</html>"), 0644)
+				},
+				Config: testAccS3BucketObjectsSync_basic(rInt, dir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketObjectsSyncKeys("huaweicloud_s3_bucket_objects_sync.sync",
+						[]string{"site/css/main.css", "site/index.html"}),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_objects_sync.sync", "files.%", "2"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket_objects_sync.sync", "pending_deletes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestS3BucketObjectsSync_pendingNotConfigurable(t *testing.T) {
+	raw := map[string]interface{}{
+		"bucket":          "bucket",
+		"source_dir":      "site",
+		"pending_uploads": []interface{}{"index.html"},
+	}
+	rawConfig, err := config.NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, errs := resourceS3BucketObjectsSync().Validate(terraform.NewResourceConfig(rawConfig))
+	if len(errs) != 1 {
+		t.Fatalf("expected pending_uploads to be rejected, got %v", errs)
+	}
+}
+
+func TestS3SyncPlan(t *testing.T) {
+	dir := testAccS3BucketObjectsSyncSource(t, map[string]string{
+		"index.html":     "<html></html>",
+		"css/main.css":   "body {}",
+		"conf/app.conf":  "key = value",
+		"img/logo":       "\x89PNG\r\n\x1a\n",
+		"unchanged.json": "{}",
+	})
+	defer os.RemoveAll(dir)
+
+	rules := []s3SyncRule{
+		{Pattern: "*.html", CacheControl: "no-cache"},
+		{Pattern: "conf/*", ACL: "public-read", ContentType: "text/plain"},
+	}
+	local, err := walkS3SyncSource(dir, s3SyncKeyPrefix("/site/"), "private", "max-age=60", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f := local["site/index.html"]; f.CacheControl != "no-cache" || f.ACL != "private" || f.ContentType != "text/html; charset=utf-8" {
+		t.Fatalf("unexpected settings of index.html: %#v", f)
+	}
+	if f := local["site/css/main.css"]; f.CacheControl != "max-age=60" || f.ContentType != "text/css; charset=utf-8" {
+		t.Fatalf("unexpected settings of main.css: %#v", f)
+	}
+	if f := local["site/conf/app.conf"]; f.ACL != "public-read" || f.ContentType != "text/plain" {
+		t.Fatalf("unexpected settings of app.conf: %#v", f)
+	}
+	if f := local["site/img/logo"]; f.ContentType != "image/png" {
+		t.Fatalf("expected the content type of logo to be detected, got %q", f.ContentType)
+	}
+
+	remote := map[string]string{
+		// The ETag of an encrypted object is not the MD5 of its content.
+		"site/unchanged.json": "a1b2c3d4e5f60718293a4b5c6d7e8f90-1",
+		"site/css/main.css":   "d41d8cd98f00b204e9800998ecf8427e",
+		"site/conf/app.conf":  local["site/conf/app.conf"].MD5,
+		"site/stale.txt":      "d41d8cd98f00b204e9800998ecf8427e",
+	}
+	synced := map[string]string{
+		"site/unchanged.json": s3SyncRecord(local["site/unchanged.json"].Fingerprint(), remote["site/unchanged.json"]),
+		// Replaced behind our back since it was synced.
+		"site/css/main.css": s3SyncRecord(local["site/css/main.css"].Fingerprint(), local["site/css/main.css"].MD5),
+		// Synced before the rule changed its ACL.
+		"site/conf/app.conf": s3SyncRecord(local["site/conf/app.conf"].MD5+"-0", remote["site/conf/app.conf"]),
+	}
+
+	uploads, deletes := s3SyncPlan(local, remote, synced, true)
+	expected := []string{"site/conf/app.conf", "site/css/main.css", "site/img/logo", "site/index.html"}
+	if !reflect.DeepEqual(uploads, expected) {
+		t.Fatalf("expected uploads %v, got %v", expected, uploads)
+	}
+	if !reflect.DeepEqual(deletes, []string{"site/stale.txt"}) {
+		t.Fatalf("expected the stale key to be deleted, got %v", deletes)
+	}
+
+	if _, deletes := s3SyncPlan(local, remote, synced, false); len(deletes) != 0 {
+		t.Fatalf("expected no deletes without delete_stale, got %v", deletes)
+	}
+}
+
+func testAccS3BucketObjectsSyncSource(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "tf-acc-s3-objects-sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func testAccCheckS3BucketObjectsSyncKeys(n string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		conn, err := config.computeS3conn(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud s3 client: %s", err)
+		}
+
+		out, err := conn.ListObjects(&s3.ListObjectsInput{
+			Bucket: aws.String(rs.Primary.Attributes["bucket"]),
+			Prefix: aws.String(s3SyncKeyPrefix(rs.Primary.Attributes["prefix"])),
+		})
+		if err != nil {
+			return fmt.Errorf("ListObjects error: %v", err)
+		}
+
+		keys := []string{}
+		for _, o := range out.Contents {
+			keys = append(keys, aws.StringValue(o.Key))
+		}
+		if !reflect.DeepEqual(keys, expected) {
+			return fmt.Errorf("Expected keys %v, got %v", expected, keys)
+		}
+		return nil
+	}
+}
+
+func testAccS3BucketObjectsSync_basic(randInt int, dir string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	force_destroy = true
+}
+
+resource "huaweicloud_s3_bucket_objects_sync" "sync" {
+	bucket = "${huaweicloud_s3_bucket.bucket.bucket}"
+	prefix = "site"
+	source_dir = "%s"
+	cache_control = "max-age=60"
+
+	rule {
+		pattern = "*.html"
+		cache_control = "no-cache"
+	}
+}
+`, randInt, dir)
+}
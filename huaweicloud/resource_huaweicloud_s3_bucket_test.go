@@ -523,6 +523,167 @@ func TestAccS3Bucket_Lifecycle(t *testing.T) {
 	})
 }
 
+func TestAccS3Bucket_LifecycleTransition(t *testing.T) {
+	rInt := acctest.RandInt()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccS3BucketConfigWithLifecycleTransition(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketExists("huaweicloud_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "lifecycle_rule.0.transition.#", "2"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "lifecycle_rule.0.expiration.#", "1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "lifecycle_rule.0.noncurrent_version_transition.#", "2"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "lifecycle_rule.0.noncurrent_version_expiration.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccS3Bucket_Replication(t *testing.T) {
+	rInt := acctest.RandInt()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckS3Replication(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccS3BucketConfigReplicationWithoutVersioning(rInt),
+				ExpectError: regexp.MustCompile("versioning must be enabled"),
+			},
+			{
+				Config: testAccS3BucketConfigReplication(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketExists("huaweicloud_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "replication_configuration.#", "1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "replication_configuration.0.agency", fmt.Sprintf("tf-test-agency-%d", rInt)),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "replication_configuration.0.rules.#", "1"),
+				),
+			},
+			{
+				Config: testAccS3BucketConfigReplicationGeneratedId(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketExists("huaweicloud_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "replication_configuration.0.rules.#", "1"),
+				),
+			},
+			{
+				Config: testAccS3BucketConfigReplicationRemoved(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3BucketExists("huaweicloud_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_s3_bucket.bucket", "replication_configuration.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestRulesHash_generatedId(t *testing.T) {
+	configured := map[string]interface{}{"id": "", "prefix": "foo", "status": "Enabled"}
+	generated := map[string]interface{}{"id": "replication-rule-1", "prefix": "foo", "status": "Enabled"}
+	if rulesHash(configured) != rulesHash(generated) {
+		t.Fatal("a rule without id should match the rule with the id generated by OBS")
+	}
+}
+
+func TestValidateS3BucketLifecycleRule(t *testing.T) {
+	transitions := func(ts ...map[string]interface{}) *schema.Set {
+		s := schema.NewSet(transitionHash, nil)
+		for _, t := range ts {
+			s.Add(t)
+		}
+		return s
+	}
+	expiration := func(days int, date string) *schema.Set {
+		return schema.NewSet(expirationHash, []interface{}{
+			map[string]interface{}{"days": days, "date": date, "expired_object_delete_marker": false},
+		})
+	}
+	days := func(class string, days int) map[string]interface{} {
+		return map[string]interface{}{"storage_class": class, "days": days, "date": ""}
+	}
+	date := func(class string, date string) map[string]interface{} {
+		return map[string]interface{}{"storage_class": class, "days": 0, "date": date}
+	}
+
+	cases := []struct {
+		Rule  map[string]interface{}
+		Error string
+	}{
+		{
+			Rule: map[string]interface{}{
+				"transition": transitions(days("WARM", 30), days("COLD", 60)),
+				"expiration": expiration(90, ""),
+			},
+		},
+		{
+			Rule: map[string]interface{}{
+				"transition": transitions(date("WARM", "2030-01-01"), date("COLD", "2030-03-01")),
+				"expiration": expiration(0, "2030-06-01"),
+			},
+		},
+		{
+			Rule: map[string]interface{}{
+				"transition": transitions(days("WARM", 60), days("COLD", 30)),
+			},
+			Error: "transition to COLD (30 days) must be after transition to WARM (60 days)",
+		},
+		{
+			Rule: map[string]interface{}{
+				"transition": transitions(days("COLD", 60)),
+				"expiration": expiration(60, ""),
+			},
+			Error: "expiration (60 days) must be after transition to COLD (60 days)",
+		},
+		{
+			Rule: map[string]interface{}{
+				"transition": transitions(days("WARM", 30)),
+				"expiration": expiration(0, "2030-06-01"),
+			},
+			Error: "must both use either days or date",
+		},
+		{
+			Rule: map[string]interface{}{
+				"transition": transitions(days("WARM", 30), days("WARM", 60)),
+			},
+			Error: "only one transition to WARM is allowed",
+		},
+		{
+			Rule: map[string]interface{}{
+				"noncurrent_version_transition": transitions(days("WARM", 30), days("COLD", 60)),
+				"noncurrent_version_expiration": expiration(45, ""),
+			},
+			Error: "noncurrent_version_expiration (45 days) must be after noncurrent_version_transition to COLD (60 days)",
+		},
+	}
+
+	for i, tc := range cases {
+		err := validateS3BucketLifecycleRule(tc.Rule)
+		if tc.Error == "" {
+			if err != nil {
+				t.Fatalf("case %d: unexpected error: %s", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.Error) {
+			t.Fatalf("case %d: expected error containing %q, got %v", i, tc.Error, err)
+		}
+	}
+}
+
 func TestS3BucketName(t *testing.T) {
 	validDnsNames := []string{
 		"foobar",
@@ -1266,6 +1427,143 @@ resource "huaweicloud_s3_bucket" "bucket" {
 `, randInt)
 }
 
+func testAccS3BucketConfigWithLifecycleTransition(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+	versioning {
+	  enabled = true
+	}
+	lifecycle_rule {
+		id = "id1"
+		prefix = "path1/"
+		enabled = true
+
+		transition {
+			days = 30
+			storage_class = "WARM"
+		}
+		transition {
+			days = 60
+			storage_class = "COLD"
+		}
+		expiration {
+			days = 365
+		}
+
+		noncurrent_version_transition {
+			days = 30
+			storage_class = "WARM"
+		}
+		noncurrent_version_transition {
+			days = 60
+			storage_class = "COLD"
+		}
+		noncurrent_version_expiration {
+			days = 180
+		}
+	}
+}
+`, randInt)
+}
+
+func testAccS3BucketConfigReplicationBase(randInt int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_iam_agency_v3" "agency" {
+	name = "tf-test-agency-%d"
+	delegated_domain_name = "op_svc_obs"
+	project_role = [
+		{
+			project = "%s"
+			roles = ["OBS Administrator"]
+		}
+	]
+}
+
+resource "huaweicloud_s3_bucket" "destination" {
+	bucket = "tf-test-bucket-destination-%d"
+	region = "%s"
+	versioning {
+		enabled = true
+	}
+}
+`, randInt, OS_REGION_NAME, randInt, OS_REPLICATION_REGION)
+}
+
+func testAccS3BucketConfigReplicationWithoutVersioning(randInt int) string {
+	return testAccS3BucketConfigReplicationBase(randInt) + fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	replication_configuration {
+		agency = "${huaweicloud_iam_agency_v3.agency.name}"
+		rules {
+			prefix = "foo"
+			status = "Enabled"
+			destination {
+				bucket = "${huaweicloud_s3_bucket.destination.bucket}"
+			}
+		}
+	}
+}
+`, randInt)
+}
+
+func testAccS3BucketConfigReplication(randInt int) string {
+	return testAccS3BucketConfigReplicationBase(randInt) + fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	versioning {
+		enabled = true
+	}
+	replication_configuration {
+		agency = "${huaweicloud_iam_agency_v3.agency.name}"
+		rules {
+			id = "foobar"
+			prefix = "foo"
+			status = "Enabled"
+			destination {
+				bucket = "${huaweicloud_s3_bucket.destination.bucket}"
+				storage_class = "WARM"
+			}
+		}
+	}
+}
+`, randInt)
+}
+
+func testAccS3BucketConfigReplicationGeneratedId(randInt int) string {
+	return testAccS3BucketConfigReplicationBase(randInt) + fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	versioning {
+		enabled = true
+	}
+	replication_configuration {
+		agency = "${huaweicloud_iam_agency_v3.agency.name}"
+		rules {
+			prefix = "bar"
+			status = "Enabled"
+			destination {
+				bucket = "${huaweicloud_s3_bucket.destination.bucket}"
+			}
+		}
+	}
+}
+`, randInt)
+}
+
+func testAccS3BucketConfigReplicationRemoved(randInt int) string {
+	return testAccS3BucketConfigReplicationBase(randInt) + fmt.Sprintf(`
+resource "huaweicloud_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	versioning {
+		enabled = true
+	}
+}
+`, randInt)
+}
+
 const testAccS3BucketConfig_namePrefix = `
 resource "huaweicloud_s3_bucket" "test" {
 	bucket_prefix = "tf-test-"
@@ -0,0 +1,145 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func resourceSFSAccessRuleV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSFSAccessRuleV2Create,
+		Read:   resourceSFSAccessRuleV2Read,
+		Delete: resourceSFSAccessRuleV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSFSAccessRuleV2Import,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"sfs_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"access_to": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"access_level": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSFSAccessLevel,
+			},
+			"access_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "cert",
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSFSAccessRuleV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	sfsClient, err := config.sfsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud File Share Client: %s", err)
+	}
+
+	ctx, cancel := timeoutContext(d, schema.TimeoutCreate)
+	defer cancel()
+
+	shareID := d.Get("sfs_id").(string)
+	rule := sfsAccessRule{
+		AccessTo:    d.Get("access_to").(string),
+		AccessType:  d.Get("access_type").(string),
+		AccessLevel: d.Get("access_level").(string),
+	}
+	grant, err := grantSFSAccessRule(ctx, sfsClient, shareID, rule)
+	if err != nil {
+		return fmt.Errorf("Error applying access rule to share file %s: %s", shareID, err)
+	}
+	d.SetId(grant.ID)
+
+	return resourceSFSAccessRuleV2Read(d, meta)
+}
+
+func resourceSFSAccessRuleV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	sfsClient, err := config.sfsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud File Share Client: %s", err)
+	}
+
+	shareID := d.Get("sfs_id").(string)
+	rule, err := getSFSAccessRule(sfsClient, shareID, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving access rules of share file %s: %s", shareID, err)
+	}
+	if rule == nil {
+		log.Printf("[WARN] Access rule %s of share file %s not found, removing from state", d.Id(), shareID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("access_to", rule.AccessTo)
+	d.Set("access_type", rule.AccessType)
+	d.Set("access_level", rule.AccessLevel)
+	d.Set("status", rule.State)
+
+	return nil
+}
+
+func resourceSFSAccessRuleV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	sfsClient, err := config.sfsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud File Share Client: %s", err)
+	}
+
+	shareID := d.Get("sfs_id").(string)
+	if err := revokeSFSAccessRule(sfsClient, shareID, d.Id()); err != nil {
+		return fmt.Errorf("Error deleting access rule %s of share file %s: %s", d.Id(), shareID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceSFSAccessRuleV2Import imports a rule by <sfs_id>/<access_rule_id>.
+func resourceSFSAccessRuleV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid format specified for SFS access rule. Format must be <sfs_id>/<access_rule_id>")
+	}
+
+	d.SetId(parts[1])
+	d.Set("sfs_id", parts[0])
+	return []*schema.ResourceData{d}, nil
+}
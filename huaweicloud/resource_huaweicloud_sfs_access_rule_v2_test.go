@@ -0,0 +1,94 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSFSAccessRuleV2_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSFSAccessRuleV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSFSAccessRuleV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSFSAccessRuleV2Exists("huaweicloud_sfs_access_rule_v2.rule_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_sfs_access_rule_v2.rule_1", "status", "active"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_sfs_file_system_v2.sfs_1", "access_rule.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSFSAccessRuleV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	sfsClient, err := config.sfsV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud sfs client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_sfs_access_rule_v2" {
+			continue
+		}
+
+		rule, err := getSFSAccessRule(sfsClient, rs.Primary.Attributes["sfs_id"], rs.Primary.ID)
+		if err == nil && rule != nil {
+			return fmt.Errorf("SFS access rule still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSFSAccessRuleV2Exists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		sfsClient, err := config.sfsV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating Huaweicloud sfs client: %s", err)
+		}
+
+		rule, err := getSFSAccessRule(sfsClient, rs.Primary.Attributes["sfs_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			return fmt.Errorf("SFS access rule not found")
+		}
+
+		return nil
+	}
+}
+
+var testAccSFSAccessRuleV2_basic = fmt.Sprintf(`
+resource "huaweicloud_sfs_file_system_v2" "sfs_1" {
+  share_proto       = "NFS"
+  size              = 1
+  name              = "sfs-test1"
+  availability_zone = "%s"
+}
+
+resource "huaweicloud_sfs_access_rule_v2" "rule_1" {
+  sfs_id       = "${huaweicloud_sfs_file_system_v2.sfs_1.id}"
+  access_to    = "%s"
+  access_level = "rw"
+}
+`, OS_AVAILABILITY_ZONE, OS_VPC_ID)
@@ -23,6 +23,7 @@ func resourceSFSFileSystemV2() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -71,18 +72,55 @@ func resourceSFSFileSystemV2() *schema.Resource {
 				ForceNew: true,
 			},
 			"access_level": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"access_rule"},
+				Deprecated:    "Use access_rule instead",
 			},
 			"access_type": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "cert",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "cert",
+				ConflictsWith: []string{"access_rule"},
+				Deprecated:    "Use access_rule instead",
 			},
 			"access_to": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"access_rule"},
+				Deprecated:    "Use access_rule instead",
+			},
+			"access_rule": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Set:      resourceSFSAccessRuleHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_to": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"access_level": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateSFSAccessLevel,
+						},
+						"access_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "cert",
+						},
+						"access_rule_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"share_access_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -106,6 +144,26 @@ func resourceSFSFileSystemV2() *schema.Resource {
 				Set:      schema.HashString,
 				Computed: true,
 			},
+			"mount_targets": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"preferred": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -152,19 +210,18 @@ func resourceSFSFileSystemV2Create(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error applying access rules to share file : %s", StateErr)
 	}
 
-	grantAccessOpts := shares.GrantAccessOpts{
-		AccessLevel: d.Get("access_level").(string),
-		AccessType:  d.Get("access_type").(string),
-		AccessTo:    d.Get("access_to").(string),
-	}
-
-	grant, accessErr := shares.GrantAccess(sfsClient, d.Id(), grantAccessOpts).ExtractAccess()
+	ctx, cancel := timeoutContext(d, schema.TimeoutCreate)
+	defer cancel()
 
-	if accessErr != nil {
-		return fmt.Errorf("Error applying access rules to share file : %s", accessErr)
+	grants := expandSFSAccessRules(d.Get("access_rule").(*schema.Set))
+	if rule, ok := legacySFSAccessRule(d); ok {
+		grants = append(grants, rule)
+	}
+	for _, rule := range grants {
+		if _, err := grantSFSAccessRule(ctx, sfsClient, d.Id(), rule); err != nil {
+			return fmt.Errorf("Error applying access rules to share file : %s", err)
+		}
 	}
-
-	log.Printf("[DEBUG] Waiting for Huaweicloud SFS File Share (%s) to become available", grant.ID)
 
 	return resourceSFSFileSystemV2Read(d, meta)
 
@@ -215,14 +272,49 @@ func resourceSFSFileSystemV2Read(d *schema.ResourceData, meta interface{}) error
 	d.Set("host", n.Host)
 	d.Set("links", n.Links)
 
-	if len(rules) > 0 {
-		rule := rules[0]
-		d.Set("share_access_id", rule.ID)
-		d.Set("access_state", rule.State)
-		d.Set("access_to", rule.AccessTo)
-		d.Set("access_type", rule.AccessType)
-		d.Set("access_level", rule.AccessLevel)
+	// The deprecated access fields track the rule they were created with,
+	// the other rules of the share are only reported in access_rule.
+	if accessTo := d.Get("access_to").(string); accessTo != "" {
+		found := false
+		for _, rule := range rules {
+			if rule.AccessTo == accessTo {
+				d.Set("share_access_id", rule.ID)
+				d.Set("access_state", rule.State)
+				d.Set("access_type", rule.AccessType)
+				d.Set("access_level", rule.AccessLevel)
+				found = true
+				break
+			}
+		}
+		if !found {
+			d.Set("access_to", "")
+			d.Set("share_access_id", "")
+			d.Set("access_state", "")
+		}
+	}
+	if err := d.Set("access_rule", flattenSFSAccessRules(rules)); err != nil {
+		return fmt.Errorf("Error setting access_rule: %s", err)
+	}
+
+	locations, err := shares.GetExportLocations(sfsClient, d.Id()).ExtractExportLocations()
+	if err != nil {
+		return fmt.Errorf("Error retrieving mount targets of Huaweicloud Share File: %s", err)
+	}
+	mountTargets := make([]map[string]interface{}, 0, len(locations))
+	for _, l := range locations {
+		if l.IsAdminOnly {
+			continue
+		}
+		mountTargets = append(mountTargets, map[string]interface{}{
+			"id":        l.ID,
+			"path":      l.Path,
+			"preferred": l.Preferred,
+		})
+	}
+	if err := d.Set("mount_targets", mountTargets); err != nil {
+		return fmt.Errorf("Error setting mount_targets: %s", err)
 	}
+
 	return nil
 }
 
@@ -239,23 +331,36 @@ func resourceSFSFileSystemV2Update(d *schema.ResourceData, meta interface{}) err
 	if d.HasChange("description") {
 		updateOpts.DisplayDescription = d.Get("description").(string)
 	}
-	if d.HasChange("access_to") {
-		deleteAccessOpts := shares.DeleteAccessOpts{AccessID: d.Get("share_access_id").(string)}
-		deny := shares.DeleteAccess(sfsClient, d.Id(), deleteAccessOpts)
-		if deny.Err != nil {
-			return fmt.Errorf("Error changing access rules for share file : %s", deny.Err)
-		}
 
-		grantAccessOpts := shares.GrantAccessOpts{
-			AccessLevel: d.Get("access_level").(string),
-			AccessType:  d.Get("access_type").(string),
-			AccessTo:    d.Get("access_to").(string),
-		}
+	ctx, cancel := timeoutContext(d, schema.TimeoutUpdate)
+	defer cancel()
 
-		_, accessErr := shares.GrantAccess(sfsClient, d.Id(), grantAccessOpts).ExtractAccess()
-
-		if accessErr != nil {
-			return fmt.Errorf("Error changing access rules for share file : %s", accessErr)
+	if d.HasChange("access_rule") {
+		o, n := d.GetChange("access_rule")
+		err := updateSFSAccessRules(ctx, sfsClient, d.Id(),
+			expandSFSAccessRules(o.(*schema.Set)), expandSFSAccessRules(n.(*schema.Set)))
+		if err != nil {
+			return fmt.Errorf("Error changing access rules for share file : %s", err)
+		}
+	}
+	if d.HasChange("access_to") || d.HasChange("access_level") || d.HasChange("access_type") {
+		var o, n []sfsAccessRule
+		if id := d.Get("share_access_id").(string); id != "" {
+			oldTo, _ := d.GetChange("access_to")
+			oldLevel, _ := d.GetChange("access_level")
+			oldType, _ := d.GetChange("access_type")
+			o = append(o, sfsAccessRule{
+				ID:          id,
+				AccessTo:    oldTo.(string),
+				AccessLevel: oldLevel.(string),
+				AccessType:  oldType.(string),
+			})
+		}
+		if rule, ok := legacySFSAccessRule(d); ok {
+			n = append(n, rule)
+		}
+		if err := updateSFSAccessRules(ctx, sfsClient, d.Id(), o, n); err != nil {
+			return fmt.Errorf("Error changing access rules for share file : %s", err)
 		}
 	}
 
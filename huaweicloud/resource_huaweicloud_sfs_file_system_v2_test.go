@@ -61,6 +61,36 @@ func TestAccSFSFileSystemV2_basic(t *testing.T) {
 	})
 }
 
+func TestAccSFSFileSystemV2_accessRules(t *testing.T) {
+	var share shares.Share
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSFSFileSystemV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSFSFileSystemV2_accessRules,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSFSFileSystemV2Exists("huaweicloud_sfs_file_system_v2.sfs_1", &share),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_sfs_file_system_v2.sfs_1", "access_rule.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"huaweicloud_sfs_file_system_v2.sfs_1", "mount_targets.0.path"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccSFSFileSystemV2_accessRulesUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSFSFileSystemV2Exists("huaweicloud_sfs_file_system_v2.sfs_1", &share),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_sfs_file_system_v2.sfs_1", "access_rule.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSFSFileSystemV2_timeout(t *testing.T) {
 	var share shares.Share
 
@@ -174,3 +204,46 @@ resource "huaweicloud_sfs_file_system_v2" "sfs_1" {
     delete = "5m"
   }
 }`, OS_AVAILABILITY_ZONE, OS_VPC_ID)
+
+var testAccSFSFileSystemV2_accessRules = fmt.Sprintf(`
+resource "huaweicloud_vpc_v1" "vpc_1" {
+  name = "vpc_sfs_test"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_sfs_file_system_v2" "sfs_1" {
+  share_proto       = "NFS"
+  size              = 1
+  name              = "sfs-test1"
+  availability_zone = "%s"
+
+  access_rule {
+    access_to    = "%s"
+    access_level = "rw"
+  }
+
+  access_rule {
+    access_to    = "${huaweicloud_vpc_v1.vpc_1.id}"
+    access_level = "ro"
+  }
+}
+`, OS_AVAILABILITY_ZONE, OS_VPC_ID)
+
+var testAccSFSFileSystemV2_accessRulesUpdate = fmt.Sprintf(`
+resource "huaweicloud_vpc_v1" "vpc_1" {
+  name = "vpc_sfs_test"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_sfs_file_system_v2" "sfs_1" {
+  share_proto       = "NFS"
+  size              = 1
+  name              = "sfs-test1"
+  availability_zone = "%s"
+
+  access_rule {
+    access_to    = "%s"
+    access_level = "ro"
+  }
+}
+`, OS_AVAILABILITY_ZONE, OS_VPC_ID)
@@ -0,0 +1,147 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceSMNMessageTemplateV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSMNMessageTemplateV2Create,
+		Read:   resourceSMNMessageTemplateV2Read,
+		Update: resourceSMNMessageTemplateV2Update,
+		Delete: resourceSMNMessageTemplateV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSMNMessageTemplateName,
+			},
+			"protocol": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{
+						"default", "email", "sms", "http", "https", "functionstage", "dms"})
+				},
+			},
+			"content": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"tag_names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"create_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"update_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func validateSMNMessageTemplateName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must be 1 to 64 letters, digits, underscores or hyphens and start with a letter or digit: %q", k, value))
+	}
+	return
+}
+
+func resourceSMNMessageTemplateV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.SmnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	protocol := d.Get("protocol").(string)
+	log.Printf("[DEBUG] Creating SMN message template %s for protocol %s", name, protocol)
+
+	id, err := createSMNMessageTemplate(client, name, protocol, d.Get("content").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating SMN message template: %s", err)
+	}
+	d.SetId(id)
+
+	return resourceSMNMessageTemplateV2Read(d, meta)
+}
+
+func resourceSMNMessageTemplateV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.SmnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+	}
+
+	template, err := getSMNMessageTemplate(client, d.Id())
+	if err != nil {
+		return CheckDeleted(d, err, "message template")
+	}
+	log.Printf("[DEBUG] Retrieved SMN message template %s: %#v", d.Id(), template)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", template.Name)
+	d.Set("protocol", template.Protocol)
+	d.Set("content", template.Content)
+	d.Set("tag_names", template.TagNames)
+	d.Set("create_time", template.CreateTime)
+	d.Set("update_time", template.UpdateTime)
+
+	return nil
+}
+
+func resourceSMNMessageTemplateV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.SmnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+	}
+
+	if d.HasChange("content") {
+		if err := updateSMNMessageTemplate(client, d.Id(), d.Get("content").(string)); err != nil {
+			return fmt.Errorf("Error updating SMN message template %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceSMNMessageTemplateV2Read(d, meta)
+}
+
+func resourceSMNMessageTemplateV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.SmnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+	}
+
+	log.Printf("[DEBUG] Deleting SMN message template %s", d.Id())
+	if err := deleteSMNMessageTemplate(client, d.Id()); err != nil {
+		return CheckDeleted(d, err, "message template")
+	}
+
+	d.SetId("")
+	return nil
+}
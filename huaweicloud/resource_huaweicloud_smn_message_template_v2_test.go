@@ -0,0 +1,115 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSMNV2MessageTemplate_basic(t *testing.T) {
+	var template smnMessageTemplate
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSMNV2MessageTemplateDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSMNV2MessageTemplate_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSMNV2MessageTemplateExists("huaweicloud_smn_message_template_v2.template_1", &template),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_smn_message_template_v2.template_1", "protocol", "email"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_smn_message_template_v2.template_1", "tag_names.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccSMNV2MessageTemplate_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"huaweicloud_smn_message_template_v2.template_1", "content",
+						"Alarm {alarm} of {resource} is {state}."),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_smn_message_template_v2.template_1", "tag_names.#", "3"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "huaweicloud_smn_message_template_v2.template_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSMNV2MessageTemplateDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	smnClient, err := config.SmnV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_smn_message_template_v2" {
+			continue
+		}
+
+		_, err := getSMNMessageTemplate(smnClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Message template still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSMNV2MessageTemplateExists(n string, template *smnMessageTemplate) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		smnClient, err := config.SmnV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+		}
+
+		found, err := getSMNMessageTemplate(smnClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Message template not found")
+		}
+
+		*template = *found
+
+		return nil
+	}
+}
+
+const testAccSMNV2MessageTemplate_basic = `
+resource "huaweicloud_smn_message_template_v2" "template_1" {
+  name     = "alarm_template"
+  protocol = "email"
+  content  = "Alarm {alarm} was triggered."
+}
+`
+
+const testAccSMNV2MessageTemplate_update = `
+resource "huaweicloud_smn_message_template_v2" "template_1" {
+  name     = "alarm_template"
+  protocol = "email"
+  content  = "Alarm {alarm} of {resource} is {state}."
+}
+`
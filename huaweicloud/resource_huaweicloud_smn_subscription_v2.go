@@ -3,9 +3,13 @@ package huaweicloud
 import (
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 
+	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/openstack/smn/v2/subscriptions"
 )
 
@@ -13,7 +17,16 @@ func resourceSubscription() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSubscriptionCreate,
 		Read:   resourceSubscriptionRead,
+		Update: resourceSubscriptionUpdate,
 		Delete: resourceSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"topic_urn": &schema.Schema{
@@ -39,6 +52,11 @@ func resourceSubscription() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"wait_for_confirmation": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"subscription_urn": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -83,6 +101,13 @@ func resourceSubscriptionCreate(d *schema.ResourceData, meta interface{}) error
 	if subscription.SubscriptionUrn != "" {
 		d.SetId(subscription.SubscriptionUrn)
 		d.Set("subscription_urn", subscription.SubscriptionUrn)
+
+		if d.Get("wait_for_confirmation").(bool) {
+			if err := waitForSubscriptionConfirmed(d, client, schema.TimeoutCreate); err != nil {
+				return err
+			}
+		}
+
 		return resourceSubscriptionRead(d, meta)
 	}
 
@@ -118,24 +143,95 @@ func resourceSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Getting subscription %s", d.Id())
 
 	id := d.Id()
-	subscriptionslist, err := subscriptions.List(client).Extract()
+	subscription, err := getSubscription(client, id)
 	if err != nil {
-		return fmt.Errorf("Error Get subscriptionslist: %s", err)
+		return CheckDeleted(d, err, "subscription")
 	}
-	log.Printf("[DEBUG] list : subscriptionslist %#v", subscriptionslist)
-	for _, subscription := range subscriptionslist {
-		if subscription.SubscriptionUrn == id {
-			log.Printf("[DEBUG] subscription: %#v", subscription)
-			d.Set("topic_urn", subscription.TopicUrn)
-			d.Set("endpoint", subscription.Endpoint)
-			d.Set("protocol", subscription.Protocol)
-			d.Set("subscription_urn", subscription.SubscriptionUrn)
-			d.Set("owner", subscription.Owner)
-			d.Set("remark", subscription.Remark)
-			d.Set("status", subscription.Status)
-		}
+	if subscription == nil {
+		log.Printf("[WARN] Subscription %s not found, removing from state", id)
+		d.SetId("")
+		return nil
 	}
 
+	log.Printf("[DEBUG] subscription: %#v", subscription)
+	d.Set("topic_urn", subscription.TopicUrn)
+	d.Set("endpoint", subscription.Endpoint)
+	d.Set("protocol", subscription.Protocol)
+	d.Set("subscription_urn", subscription.SubscriptionUrn)
+	d.Set("owner", subscription.Owner)
+	d.Set("remark", subscription.Remark)
+	d.Set("status", subscription.Status)
+
 	log.Printf("[DEBUG] Successfully get subscription %s", id)
 	return nil
 }
+
+func resourceSubscriptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.SmnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud smn client: %s", err)
+	}
+
+	if d.HasChange("wait_for_confirmation") && d.Get("wait_for_confirmation").(bool) {
+		if err := waitForSubscriptionConfirmed(d, client, schema.TimeoutUpdate); err != nil {
+			return err
+		}
+	}
+
+	return resourceSubscriptionRead(d, meta)
+}
+
+// The statuses of a subscription waited for, a canceled subscription is 3.
+const (
+	subscriptionUnconfirmed         = 0
+	subscriptionConfirmed           = 1
+	subscriptionConfirmationNotNeed = 2
+)
+
+// subscriptionTopicUrn returns the urn of the topic of a subscription, the
+// subscription urn is that of the topic followed by the subscription id.
+func subscriptionTopicUrn(subscriptionUrn string) string {
+	if i := strings.LastIndex(subscriptionUrn, ":"); i > 0 {
+		return subscriptionUrn[:i]
+	}
+	return subscriptionUrn
+}
+
+// getSubscription looks the subscription up among those of its topic, it
+// returns nil when the topic has no such subscription.
+func getSubscription(client *golangsdk.ServiceClient, subscriptionUrn string) (*subscriptions.SubscriptionGet, error) {
+	list, err := listSMNTopicSubscriptions(client, subscriptionTopicUrn(subscriptionUrn))
+	if err != nil {
+		return nil, err
+	}
+	for _, subscription := range list {
+		if subscription.SubscriptionUrn == subscriptionUrn {
+			return &subscription, nil
+		}
+	}
+	return nil, nil
+}
+
+// waitForSubscriptionConfirmed waits until the owner of the endpoint has
+// confirmed the subscription, or the protocol does not need a confirmation.
+func waitForSubscriptionConfirmed(d *schema.ResourceData, client *golangsdk.ServiceClient, timeout string) error {
+	ctx, cancel := timeoutContext(d, timeout)
+	defer cancel()
+
+	id := d.Id()
+	_, err := waitForStatus(ctx, "subscription "+id,
+		[]string{strconv.Itoa(subscriptionUnconfirmed)},
+		[]string{strconv.Itoa(subscriptionConfirmed), strconv.Itoa(subscriptionConfirmationNotNeed)},
+		func() (interface{}, string, error) {
+			subscription, err := getSubscription(client, id)
+			if err != nil {
+				return nil, "", err
+			}
+			if subscription == nil {
+				return nil, "", fmt.Errorf("subscription %s not found", id)
+			}
+			return subscription, strconv.Itoa(subscription.Status), nil
+		})
+	return err
+}
@@ -31,6 +31,12 @@ func TestAccSMNV2Subscription_basic(t *testing.T) {
 						"13600000000"),
 				),
 			},
+			resource.TestStep{
+				ResourceName:            "huaweicloud_smn_subscription_v2.subscription_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_confirmation"},
+			},
 		},
 	})
 }
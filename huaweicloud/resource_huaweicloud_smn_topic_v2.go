@@ -28,6 +28,16 @@ func resourceTopic() *schema.Resource {
 				Optional: true,
 				ForceNew: false,
 			},
+			"access_policy": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateJsonString,
+				DiffSuppressFunc: suppressEquivalentJsonDiffs,
+			},
+			"introduction": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"topic_urn": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -76,6 +86,13 @@ func resourceTopicCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Create : topic.TopicUrn %s", topic.TopicUrn)
 	if topic.TopicUrn != "" {
 		d.SetId(topic.TopicUrn)
+		for _, name := range smnTopicAttributes {
+			if v, ok := d.GetOk(name); ok {
+				if err := updateSMNTopicAttribute(client, topic.TopicUrn, name, v.(string)); err != nil {
+					return fmt.Errorf("Error setting %s of topic %s: %s", name, topic.TopicUrn, err)
+				}
+			}
+		}
 		return resourceTopicRead(d, meta)
 	}
 
@@ -104,6 +121,14 @@ func resourceTopicRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("update_time", topicGet.UpdateTime)
 	d.Set("create_time", topicGet.CreateTime)
 
+	attributes, err := getSMNTopicAttributes(client, topicUrn)
+	if err != nil {
+		return fmt.Errorf("Error retrieving attributes of topic %s: %s", topicUrn, err)
+	}
+	for _, name := range smnTopicAttributes {
+		d.Set(name, attributes[name])
+	}
+
 	return nil
 }
 
@@ -136,20 +161,29 @@ func resourceTopicUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Updating topic %s", d.Id())
 	id := d.Id()
 
-	var updateOpts topics.UpdateOps
-	if d.HasChange("display_name") {
-		updateOpts.DisplayName = d.Get("display_name").(string)
+	for _, name := range smnTopicAttributes {
+		if d.HasChange(name) {
+			if err := updateSMNTopicAttribute(client, id, name, d.Get(name).(string)); err != nil {
+				return fmt.Errorf("Error updating %s of topic %s: %s", name, id, err)
+			}
+		}
 	}
 
-	topic, err := topics.Update(client, updateOpts, id).Extract()
-	if err != nil {
-		return fmt.Errorf("Error updating topic from result: %s", err)
+	if d.HasChange("display_name") {
+		updateOpts := topics.UpdateOps{
+			DisplayName: d.Get("display_name").(string),
+		}
+
+		topic, err := topics.Update(client, updateOpts, id).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating topic from result: %s", err)
+		}
+
+		log.Printf("[DEBUG] Update : topic.TopicUrn: %s", topic.TopicUrn)
+		if topic.TopicUrn != "" {
+			d.SetId(topic.TopicUrn)
+		}
 	}
 
-	log.Printf("[DEBUG] Update : topic.TopicUrn: %s", topic.TopicUrn)
-	if topic.TopicUrn != "" {
-		d.SetId(topic.TopicUrn)
-		return resourceTopicRead(d, meta)
-	}
-	return nil
+	return resourceTopicRead(d, meta)
 }
@@ -42,6 +42,37 @@ func TestAccSMNV2Topic_basic(t *testing.T) {
 	})
 }
 
+func TestAccSMNV2Topic_attributes(t *testing.T) {
+	var topic topics.TopicGet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSMNTopicV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TestAccSMNV2TopicConfig_attributes,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSMNV2TopicExists("huaweicloud_smn_topic_v2.topic_1", &topic),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_smn_topic_v2.topic_1", "introduction", "Alarms of the production services"),
+					resource.TestCheckResourceAttrSet(
+						"huaweicloud_smn_topic_v2.topic_1", "access_policy"),
+				),
+			},
+			resource.TestStep{
+				Config: TestAccSMNV2TopicConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"huaweicloud_smn_topic_v2.topic_1", "introduction", ""),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_smn_topic_v2.topic_1", "access_policy", ""),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSMNTopicV2Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	smnClient, err := config.SmnV2Client(OS_REGION_NAME)
@@ -108,3 +139,28 @@ resource "huaweicloud_smn_topic_v2" "topic_1" {
   display_name    = "The update display name of topic_1"
 }
 `
+
+var TestAccSMNV2TopicConfig_attributes = `
+resource "huaweicloud_smn_topic_v2" "topic_1" {
+  name		  = "topic_1"
+  display_name    = "The display name of topic_1"
+  introduction    = "Alarms of the production services"
+  access_policy   = <<POLICY
+{
+  "Version": "2016-09-07",
+  "Id": "__default_policy_ID",
+  "Statement": [
+    {
+      "Sid": "__service_pub_0",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": ["obs", "ces"]
+      },
+      "Action": ["SMN:Publish", "SMN:QueryTopicDetail"],
+      "Resource": "*"
+    }
+  ]
+}
+POLICY
+}
+`
@@ -1,6 +1,7 @@
 package huaweicloud
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -243,16 +244,10 @@ func resourceVpcEIPV1Delete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error unbinding eip:%s to port: %s", d.Id(), err)
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"ACTIVE"},
-		Target:     []string{"DELETED"},
-		Refresh:    waitForEIPDelete(networkingClient, d.Id()),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err = waitForStatus(ctx, "EIP "+d.Id(), []string{"ACTIVE"}, []string{"DELETED"},
+		waitForEIPDelete(networkingClient, d.Id()))
 	if err != nil {
 		return fmt.Errorf("Error deleting EIP: %s", err)
 	}
@@ -273,6 +268,9 @@ func getEIPStatus(networkingClient *golangsdk.ServiceClient, eId string) resourc
 		if e.Status == "DOWN" || e.Status == "ACTIVE" {
 			return e, "ACTIVE", nil
 		}
+		if e.Status == "ERROR" {
+			return e, e.Status, nil
+		}
 
 		return e, "", nil
 	}
@@ -368,14 +366,9 @@ func unbindToPort(d *schema.ResourceData, eipID string, networkingClient *golang
 }
 
 func waitForEIPActive(networkingClient *golangsdk.ServiceClient, eipID string, timeout time.Duration) error {
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"ACTIVE"},
-		Refresh:    getEIPStatus(networkingClient, eipID),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	_, err := stateConf.WaitForState()
+	_, err := waitForStatus(ctx, "EIP "+eipID, nil, []string{"ACTIVE"}, getEIPStatus(networkingClient, eipID))
 	return err
 }
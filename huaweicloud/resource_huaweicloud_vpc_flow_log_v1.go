@@ -0,0 +1,195 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func resourceVpcFlowLogV1() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcFlowLogV1Create,
+		Read:   resourceVpcFlowLogV1Read,
+		Update: resourceVpcFlowLogV1Update,
+		Delete: resourceVpcFlowLogV1Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateName,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"vpc", "network", "port"})
+				},
+			},
+			"resource_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"traffic_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "all",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"all", "accept", "reject"})
+				},
+			},
+			"log_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"log_topic_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpcFlowLogV1Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	createOpts := vpcFlowLog{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		ResourceType: d.Get("resource_type").(string),
+		ResourceID:   d.Get("resource_id").(string),
+		TrafficType:  d.Get("traffic_type").(string),
+		LogGroupID:   d.Get("log_group_id").(string),
+		LogTopicID:   d.Get("log_topic_id").(string),
+	}
+	log.Printf("[DEBUG] Create VPC flow log options: %#v", createOpts)
+
+	flowLog, err := createVpcFlowLog(vpcClient, createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud VPC flow log: %s", err)
+	}
+	d.SetId(flowLog.ID)
+	log.Printf("[INFO] VPC flow log ID: %s", flowLog.ID)
+
+	// Flow logs are created enabled.
+	if !d.Get("enabled").(bool) {
+		enabled := false
+		if _, err := updateVpcFlowLog(vpcClient, d.Id(), vpcFlowLogUpdate{AdminStateUp: &enabled}); err != nil {
+			return fmt.Errorf("Error disabling Huaweicloud VPC flow log %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceVpcFlowLogV1Read(d, meta)
+}
+
+func resourceVpcFlowLogV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	flowLog, err := getVpcFlowLog(vpcClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Huaweicloud VPC flow log: %s", err)
+	}
+
+	d.Set("name", flowLog.Name)
+	d.Set("description", flowLog.Description)
+	d.Set("resource_type", flowLog.ResourceType)
+	d.Set("resource_id", flowLog.ResourceID)
+	d.Set("traffic_type", flowLog.TrafficType)
+	d.Set("log_group_id", flowLog.LogGroupID)
+	d.Set("log_topic_id", flowLog.LogTopicID)
+	d.Set("enabled", flowLog.AdminStateUp == nil || *flowLog.AdminStateUp)
+	d.Set("status", flowLog.Status)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpcFlowLogV1Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	var updateOpts vpcFlowLogUpdate
+	if d.HasChange("name") {
+		updateOpts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		updateOpts.Description = &description
+	}
+	if d.HasChange("enabled") {
+		enabled := d.Get("enabled").(bool)
+		updateOpts.AdminStateUp = &enabled
+	}
+
+	_, err = updateVpcFlowLog(vpcClient, d.Id(), updateOpts)
+	if err != nil {
+		return fmt.Errorf("Error updating Huaweicloud VPC flow log: %s", err)
+	}
+
+	return resourceVpcFlowLogV1Read(d, meta)
+}
+
+func resourceVpcFlowLogV1Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	err = deleteVpcFlowLog(vpcClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error deleting Huaweicloud VPC flow log: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,122 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVpcFlowLogV1_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-flowlog-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFlowLog(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcFlowLogV1Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcFlowLogV1_basic(rName, "flow log", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_flow_log_v1.flow_log_1", "description", "flow log"),
+					testAccCheckVpcFlowLogV1Exists("huaweicloud_vpc_flow_log_v1.flow_log_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_flow_log_v1.flow_log_1", "resource_type", "vpc"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_flow_log_v1.flow_log_1", "traffic_type", "reject"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_flow_log_v1.flow_log_1", "enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpcFlowLogV1_basic(rName, "", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_flow_log_v1.flow_log_1", "description", ""),
+					testAccCheckVpcFlowLogV1Exists("huaweicloud_vpc_flow_log_v1.flow_log_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_flow_log_v1.flow_log_1", "enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "huaweicloud_vpc_flow_log_v1.flow_log_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckVpcFlowLogV1Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	vpcClient, err := config.networkingV1Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_vpc_flow_log_v1" {
+			continue
+		}
+
+		_, err := getVpcFlowLog(vpcClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("VPC flow log still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcFlowLogV1Exists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		vpcClient, err := config.networkingV1Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+		}
+
+		found, err := getVpcFlowLog(vpcClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VPC flow log not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccVpcFlowLogV1_basic(rName, description string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc_v1" "vpc_1" {
+  name = "%s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_vpc_flow_log_v1" "flow_log_1" {
+  name          = "%s"
+  description   = "%s"
+  resource_type = "vpc"
+  resource_id   = "${huaweicloud_vpc_v1.vpc_1.id}"
+  traffic_type  = "reject"
+  log_group_id  = "%s"
+  log_topic_id  = "%s"
+  enabled       = %t
+}
+`, rName, rName, description, OS_LTS_GROUP_ID, OS_LTS_TOPIC_ID, enabled)
+}
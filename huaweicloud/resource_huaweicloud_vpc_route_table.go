@@ -0,0 +1,284 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func resourceVpcRouteTable() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcRouteTableCreate,
+		Read:   resourceVpcRouteTableRead,
+		Update: resourceVpcRouteTableUpdate,
+		Delete: resourceVpcRouteTableDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateName,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"subnets": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"route": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCIDR,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								return ValidateStringList(v, k, []string{
+									"ecs", "eni", "vip", "nat", "peering", "vpn", "dc", "cc"})
+							},
+						},
+						"nexthop": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVpcRouteTableSubnets(v *schema.Set) []string {
+	var subnets []string
+	for _, v := range v.List() {
+		subnets = append(subnets, v.(string))
+	}
+	return subnets
+}
+
+func expandVpcRoutes(s *schema.Set) []vpcRoute {
+	routes := make([]vpcRoute, 0, s.Len())
+	for _, v := range s.List() {
+		m := v.(map[string]interface{})
+		routes = append(routes, vpcRoute{
+			Destination: m["destination"].(string),
+			Type:        m["type"].(string),
+			NextHop:     m["nexthop"].(string),
+			Description: m["description"].(string),
+		})
+	}
+	return routes
+}
+
+func flattenVpcRoutes(routes []vpcRoute) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(routes))
+	for _, route := range routes {
+		result = append(result, map[string]interface{}{
+			"destination": route.Destination,
+			"type":        route.Type,
+			"nexthop":     route.NextHop,
+			"description": route.Description,
+		})
+	}
+	return result
+}
+
+// diffVpcRoutes groups the changes from the old routes to the new ones the
+// way the API expects them: routes are identified by their destination.
+func diffVpcRoutes(old, new []vpcRoute) map[string][]vpcRoute {
+	oldRoutes := make(map[string]vpcRoute)
+	for _, r := range old {
+		oldRoutes[r.Destination] = r
+	}
+	newRoutes := make(map[string]bool)
+
+	changes := make(map[string][]vpcRoute)
+	for _, r := range new {
+		newRoutes[r.Destination] = true
+		o, ok := oldRoutes[r.Destination]
+		switch {
+		case !ok:
+			changes["add"] = append(changes["add"], r)
+		case o != r:
+			changes["mod"] = append(changes["mod"], r)
+		}
+	}
+	for _, r := range old {
+		if !newRoutes[r.Destination] {
+			changes["del"] = append(changes["del"], vpcRoute{Destination: r.Destination})
+		}
+	}
+	return changes
+}
+
+func resourceVpcRouteTableCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	createOpts := vpcRouteTable{
+		VpcID:       d.Get("vpc_id").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Routes:      expandVpcRoutes(d.Get("route").(*schema.Set)),
+	}
+	log.Printf("[DEBUG] Create VPC route table options: %#v", createOpts)
+
+	routeTable, err := createVpcRouteTable(vpcClient, createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud VPC route table: %s", err)
+	}
+	d.SetId(routeTable.ID)
+	log.Printf("[INFO] VPC route table ID: %s", routeTable.ID)
+
+	subnets := resourceVpcRouteTableSubnets(d.Get("subnets").(*schema.Set))
+	if err := associateVpcRouteTableSubnets(vpcClient, d.Id(), subnets, nil); err != nil {
+		return fmt.Errorf("Error associating subnets with Huaweicloud VPC route table %s: %s", d.Id(), err)
+	}
+
+	return resourceVpcRouteTableRead(d, meta)
+}
+
+func resourceVpcRouteTableRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	routeTable, err := getVpcRouteTable(vpcClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Huaweicloud VPC route table: %s", err)
+	}
+
+	subnets := make([]string, 0, len(routeTable.Subnets))
+	for _, s := range routeTable.Subnets {
+		subnets = append(subnets, s.ID)
+	}
+
+	d.Set("vpc_id", routeTable.VpcID)
+	d.Set("name", routeTable.Name)
+	d.Set("description", routeTable.Description)
+	d.Set("region", GetRegion(d, config))
+	if err := d.Set("subnets", subnets); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving subnets to state for Huaweicloud VPC route table (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("route", flattenVpcRoutes(routeTable.Routes)); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving route to state for Huaweicloud VPC route table (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceVpcRouteTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	if d.HasChange("name") || d.HasChange("description") || d.HasChange("route") {
+		updateOpts := vpcRouteTableUpdate{
+			Name: d.Get("name").(string),
+		}
+		if d.HasChange("description") {
+			description := d.Get("description").(string)
+			updateOpts.Description = &description
+		}
+		if d.HasChange("route") {
+			o, n := d.GetChange("route")
+			updateOpts.Routes = diffVpcRoutes(expandVpcRoutes(o.(*schema.Set)), expandVpcRoutes(n.(*schema.Set)))
+		}
+		log.Printf("[DEBUG] Update VPC route table %s options: %#v", d.Id(), updateOpts)
+
+		if _, err := updateVpcRouteTable(vpcClient, d.Id(), updateOpts); err != nil {
+			return fmt.Errorf("Error updating Huaweicloud VPC route table %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("subnets") {
+		o, n := d.GetChange("subnets")
+		oldSubnets, newSubnets := o.(*schema.Set), n.(*schema.Set)
+		associate := resourceVpcRouteTableSubnets(newSubnets.Difference(oldSubnets))
+		disassociate := resourceVpcRouteTableSubnets(oldSubnets.Difference(newSubnets))
+		if err := associateVpcRouteTableSubnets(vpcClient, d.Id(), associate, disassociate); err != nil {
+			return fmt.Errorf("Error updating the subnets of Huaweicloud VPC route table %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceVpcRouteTableRead(d, meta)
+}
+
+func resourceVpcRouteTableDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	// A route table cannot be deleted while subnets are associated with it.
+	routeTable, err := getVpcRouteTable(vpcClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Huaweicloud VPC route table: %s", err)
+	}
+	subnets := make([]string, 0, len(routeTable.Subnets))
+	for _, s := range routeTable.Subnets {
+		subnets = append(subnets, s.ID)
+	}
+	if err := associateVpcRouteTableSubnets(vpcClient, d.Id(), nil, subnets); err != nil {
+		return fmt.Errorf("Error disassociating subnets from Huaweicloud VPC route table %s: %s", d.Id(), err)
+	}
+
+	err = deleteVpcRouteTable(vpcClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error deleting Huaweicloud VPC route table: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
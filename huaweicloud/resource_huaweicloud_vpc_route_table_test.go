@@ -0,0 +1,210 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVpcRouteTable_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-rtb-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcRouteTableDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcRouteTable_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcRouteTableExists("huaweicloud_vpc_route_table.rtb_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_route_table.rtb_1", "name", rName),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_route_table.rtb_1", "route.#", "1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_route_table.rtb_1", "subnets.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpcRouteTable_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcRouteTableExists("huaweicloud_vpc_route_table.rtb_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_route_table.rtb_1", "description", "dmz egress"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_route_table.rtb_1", "route.#", "2"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpc_route_table.rtb_1", "subnets.#", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "huaweicloud_vpc_route_table.rtb_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestDiffVpcRoutes(t *testing.T) {
+	old := []vpcRoute{
+		{Destination: "172.16.0.0/16", Type: "peering", NextHop: "peering-1"},
+		{Destination: "172.17.0.0/16", Type: "peering", NextHop: "peering-1"},
+		{Destination: "0.0.0.0/0", Type: "nat", NextHop: "nat-1"},
+	}
+	new := []vpcRoute{
+		{Destination: "172.16.0.0/16", Type: "peering", NextHop: "peering-1"},
+		{Destination: "0.0.0.0/0", Type: "vip", NextHop: "192.168.0.10"},
+		{Destination: "10.0.0.0/8", Type: "ecs", NextHop: "server-1"},
+	}
+
+	expected := map[string][]vpcRoute{
+		"add": {{Destination: "10.0.0.0/8", Type: "ecs", NextHop: "server-1"}},
+		"mod": {{Destination: "0.0.0.0/0", Type: "vip", NextHop: "192.168.0.10"}},
+		"del": {{Destination: "172.17.0.0/16"}},
+	}
+	if changes := diffVpcRoutes(old, new); !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, changes)
+	}
+
+	if changes := diffVpcRoutes(old, old); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %#v", changes)
+	}
+}
+
+func testAccCheckVpcRouteTableDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	vpcClient, err := config.networkingV1Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_vpc_route_table" {
+			continue
+		}
+
+		_, err := getVpcRouteTable(vpcClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("VPC route table still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcRouteTableExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		vpcClient, err := config.networkingV1Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating Huaweicloud vpc client: %s", err)
+		}
+
+		found, err := getVpcRouteTable(vpcClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VPC route table not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccVpcRouteTable_base(rName string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc_v1" "vpc_1" {
+  name = "%s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_vpc_v1" "vpc_2" {
+  name = "%s-peer"
+  cidr = "172.16.0.0/16"
+}
+
+resource "huaweicloud_vpc_peering_connection_v2" "peering_1" {
+  name        = "%s"
+  vpc_id      = "${huaweicloud_vpc_v1.vpc_1.id}"
+  peer_vpc_id = "${huaweicloud_vpc_v1.vpc_2.id}"
+}
+
+resource "huaweicloud_vpc_subnet_v1" "subnet_1" {
+  name       = "%s-1"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+  vpc_id     = "${huaweicloud_vpc_v1.vpc_1.id}"
+}
+
+resource "huaweicloud_vpc_subnet_v1" "subnet_2" {
+  name       = "%s-2"
+  cidr       = "192.168.1.0/24"
+  gateway_ip = "192.168.1.1"
+  vpc_id     = "${huaweicloud_vpc_v1.vpc_1.id}"
+}
+`, rName, rName, rName, rName, rName)
+}
+
+func testAccVpcRouteTable_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_vpc_route_table" "rtb_1" {
+  name    = "%s"
+  vpc_id  = "${huaweicloud_vpc_v1.vpc_1.id}"
+  subnets = ["${huaweicloud_vpc_subnet_v1.subnet_1.id}"]
+
+  route {
+    destination = "172.16.0.0/24"
+    type        = "peering"
+    nexthop     = "${huaweicloud_vpc_peering_connection_v2.peering_1.id}"
+  }
+}
+`, testAccVpcRouteTable_base(rName), rName)
+}
+
+func testAccVpcRouteTable_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_vpc_route_table" "rtb_1" {
+  name        = "%s"
+  description = "dmz egress"
+  vpc_id      = "${huaweicloud_vpc_v1.vpc_1.id}"
+  subnets     = [
+    "${huaweicloud_vpc_subnet_v1.subnet_1.id}",
+    "${huaweicloud_vpc_subnet_v1.subnet_2.id}",
+  ]
+
+  route {
+    destination = "172.16.0.0/24"
+    type        = "peering"
+    nexthop     = "${huaweicloud_vpc_peering_connection_v2.peering_1.id}"
+    description = "peer vpc"
+  }
+
+  route {
+    destination = "172.16.1.0/24"
+    type        = "peering"
+    nexthop     = "${huaweicloud_vpc_peering_connection_v2.peering_1.id}"
+  }
+}
+`, testAccVpcRouteTable_base(rName), rName)
+}
@@ -0,0 +1,153 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceVpnEndpointGroupV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpnEndpointGroupV2Create,
+		Read:   resourceVpnEndpointGroupV2Read,
+		Update: resourceVpnEndpointGroupV2Update,
+		Delete: resourceVpnEndpointGroupV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"subnet", "cidr", "vlan", "network", "router"})
+				},
+			},
+			"endpoints": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpnEndpointGroupV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	createOpts := vpnEndpointGroup{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Type:        d.Get("type").(string),
+		Endpoints:   expandStringSet(d.Get("endpoints").(*schema.Set)),
+		TenantID:    d.Get("tenant_id").(string),
+	}
+	log.Printf("[DEBUG] Create endpoint group: %#v", createOpts)
+
+	var group vpnEndpointGroup
+	if err := vpnEndpointGroups.create(vpnClient, createOpts, &group); err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud endpoint group: %s", err)
+	}
+	log.Printf("[DEBUG] Endpoint group created: %#v", group)
+	d.SetId(group.ID)
+
+	return resourceVpnEndpointGroupV2Read(d, meta)
+}
+
+func resourceVpnEndpointGroupV2Read(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Retrieve information about endpoint group: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var group vpnEndpointGroup
+	if err := vpnEndpointGroups.get(vpnClient, d.Id(), &group); err != nil {
+		return CheckDeleted(d, err, "endpoint group")
+	}
+
+	log.Printf("[DEBUG] Read HuaweiCloud endpoint group %s: %#v", d.Id(), group)
+
+	d.Set("name", group.Name)
+	d.Set("description", group.Description)
+	d.Set("type", group.Type)
+	if err := d.Set("endpoints", group.Endpoints); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving endpoints to state for HuaweiCloud endpoint group (%s): %s", d.Id(), err)
+	}
+	d.Set("tenant_id", group.TenantID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpnEndpointGroupV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	// Only the name and the description of an endpoint group can be updated.
+	var opts vpnEndpointGroup
+	if d.HasChange("name") {
+		opts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts.Description = d.Get("description").(string)
+	}
+
+	log.Printf("[DEBUG] Updating endpoint group with id %s: %#v", d.Id(), opts)
+	if err := vpnEndpointGroups.update(vpnClient, d.Id(), opts); err != nil {
+		return fmt.Errorf("Error updating HuaweiCloud endpoint group: %s", err)
+	}
+
+	return resourceVpnEndpointGroupV2Read(d, meta)
+}
+
+func resourceVpnEndpointGroupV2Delete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy endpoint group: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	if err := vpnEndpointGroups.delete(vpnClient, d.Id()); err != nil {
+		return CheckDeleted(d, err, "endpoint group")
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,111 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func TestAccVpnEndpointGroupV2_basic(t *testing.T) {
+	var v vpnEndpointGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnEndpointGroupV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpnEndpointGroupV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnEndpointGroupV2Exists("huaweicloud_vpnaas_endpoint_group_v2.group_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_endpoint_group_v2.group_1", "name", "group_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_endpoint_group_v2.group_1", "type", "cidr"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_endpoint_group_v2.group_1", "endpoints.#", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpnEndpointGroupV2_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnEndpointGroupV2Exists("huaweicloud_vpnaas_endpoint_group_v2.group_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_endpoint_group_v2.group_1", "name", "group_1_updated"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_endpoint_group_v2.group_1", "endpoints.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpnEndpointGroupV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_vpnaas_endpoint_group_v2" {
+			continue
+		}
+		var found vpnEndpointGroup
+		err = vpnEndpointGroups.get(vpnClient, rs.Primary.ID, &found)
+		if err == nil {
+			return fmt.Errorf("Endpoint group (%s) still exists.", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccCheckVpnEndpointGroupV2Exists(n string, found *vpnEndpointGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+		}
+
+		if err := vpnEndpointGroups.get(vpnClient, rs.Primary.ID, found); err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Endpoint group not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccVpnEndpointGroupV2_basic = `
+resource "huaweicloud_vpnaas_endpoint_group_v2" "group_1" {
+  name = "group_1"
+  type = "cidr"
+  endpoints = ["10.2.0.0/24", "10.3.0.0/24"]
+}
+`
+
+const testAccVpnEndpointGroupV2_update = `
+resource "huaweicloud_vpnaas_endpoint_group_v2" "group_1" {
+  name = "group_1_updated"
+  type = "cidr"
+  endpoints = ["10.2.0.0/24", "10.3.0.0/24"]
+}
+`
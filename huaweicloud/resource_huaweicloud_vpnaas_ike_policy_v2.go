@@ -0,0 +1,204 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceVpnIKEPolicyV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpnIKEPolicyV2Create,
+		Read:   resourceVpnIKEPolicyV2Read,
+		Update: resourceVpnIKEPolicyV2Update,
+		Delete: resourceVpnIKEPolicyV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"auth_algorithm": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "sha1",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"md5", "sha1", "sha256", "sha384", "sha512"})
+				},
+			},
+			"encryption_algorithm": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "aes-128",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"3des", "aes-128", "aes-192", "aes-256"})
+				},
+			},
+			"pfs": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "group5",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"group2", "group5", "group14"})
+				},
+			},
+			"ike_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "v1",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"v1", "v2"})
+				},
+			},
+			"phase1_negotiation_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "main",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"main", "aggressive"})
+				},
+			},
+			"lifetime": vpnLifetimeSchema(),
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpnIKEPolicyV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	createOpts := vpnIKEPolicy{
+		Name:                  d.Get("name").(string),
+		Description:           d.Get("description").(string),
+		AuthAlgorithm:         d.Get("auth_algorithm").(string),
+		EncryptionAlgorithm:   d.Get("encryption_algorithm").(string),
+		PFS:                   d.Get("pfs").(string),
+		IKEVersion:            d.Get("ike_version").(string),
+		Phase1NegotiationMode: d.Get("phase1_negotiation_mode").(string),
+		Lifetime:              expandVpnLifetime(d),
+		TenantID:              d.Get("tenant_id").(string),
+	}
+	log.Printf("[DEBUG] Create IKE policy: %#v", createOpts)
+
+	var policy vpnIKEPolicy
+	if err := vpnIKEPolicies.create(vpnClient, createOpts, &policy); err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud IKE policy: %s", err)
+	}
+	log.Printf("[DEBUG] IKE policy created: %#v", policy)
+	d.SetId(policy.ID)
+
+	return resourceVpnIKEPolicyV2Read(d, meta)
+}
+
+func resourceVpnIKEPolicyV2Read(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Retrieve information about IKE policy: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var policy vpnIKEPolicy
+	if err := vpnIKEPolicies.get(vpnClient, d.Id(), &policy); err != nil {
+		return CheckDeleted(d, err, "IKE policy")
+	}
+
+	log.Printf("[DEBUG] Read HuaweiCloud IKE policy %s: %#v", d.Id(), policy)
+
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("auth_algorithm", policy.AuthAlgorithm)
+	d.Set("encryption_algorithm", policy.EncryptionAlgorithm)
+	d.Set("pfs", policy.PFS)
+	d.Set("ike_version", policy.IKEVersion)
+	d.Set("phase1_negotiation_mode", policy.Phase1NegotiationMode)
+	if err := d.Set("lifetime", flattenVpnLifetime(policy.Lifetime)); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving lifetime to state for HuaweiCloud IKE policy (%s): %s", d.Id(), err)
+	}
+	d.Set("tenant_id", policy.TenantID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpnIKEPolicyV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var opts vpnIKEPolicy
+	if d.HasChange("name") {
+		opts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts.Description = d.Get("description").(string)
+	}
+	if d.HasChange("auth_algorithm") {
+		opts.AuthAlgorithm = d.Get("auth_algorithm").(string)
+	}
+	if d.HasChange("encryption_algorithm") {
+		opts.EncryptionAlgorithm = d.Get("encryption_algorithm").(string)
+	}
+	if d.HasChange("pfs") {
+		opts.PFS = d.Get("pfs").(string)
+	}
+	if d.HasChange("ike_version") {
+		opts.IKEVersion = d.Get("ike_version").(string)
+	}
+	if d.HasChange("phase1_negotiation_mode") {
+		opts.Phase1NegotiationMode = d.Get("phase1_negotiation_mode").(string)
+	}
+	if d.HasChange("lifetime") {
+		opts.Lifetime = expandVpnLifetime(d)
+	}
+
+	log.Printf("[DEBUG] Updating IKE policy with id %s: %#v", d.Id(), opts)
+	if err := vpnIKEPolicies.update(vpnClient, d.Id(), opts); err != nil {
+		return fmt.Errorf("Error updating HuaweiCloud IKE policy: %s", err)
+	}
+
+	return resourceVpnIKEPolicyV2Read(d, meta)
+}
+
+func resourceVpnIKEPolicyV2Delete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy IKE policy: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	if err := vpnIKEPolicies.delete(vpnClient, d.Id()); err != nil {
+		return CheckDeleted(d, err, "IKE policy")
+	}
+
+	d.SetId("")
+	return nil
+}
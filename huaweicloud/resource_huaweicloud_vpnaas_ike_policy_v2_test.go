@@ -0,0 +1,119 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func TestAccVpnIKEPolicyV2_basic(t *testing.T) {
+	var v vpnIKEPolicy
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnIKEPolicyV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpnIKEPolicyV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIKEPolicyV2Exists("huaweicloud_vpnaas_ike_policy_v2.policy_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ike_policy_v2.policy_1", "name", "ike_policy_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ike_policy_v2.policy_1", "pfs", "group5"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ike_policy_v2.policy_1", "lifetime.0.value", "3600"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpnIKEPolicyV2_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIKEPolicyV2Exists("huaweicloud_vpnaas_ike_policy_v2.policy_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ike_policy_v2.policy_1", "name", "ike_policy_1_updated"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ike_policy_v2.policy_1", "pfs", "group14"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ike_policy_v2.policy_1", "lifetime.0.value", "7200"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpnIKEPolicyV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_vpnaas_ike_policy_v2" {
+			continue
+		}
+		var found vpnIKEPolicy
+		err = vpnIKEPolicies.get(vpnClient, rs.Primary.ID, &found)
+		if err == nil {
+			return fmt.Errorf("IKE policy (%s) still exists.", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccCheckVpnIKEPolicyV2Exists(n string, found *vpnIKEPolicy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+		}
+
+		if err := vpnIKEPolicies.get(vpnClient, rs.Primary.ID, found); err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("IKE policy not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccVpnIKEPolicyV2_basic = `
+resource "huaweicloud_vpnaas_ike_policy_v2" "policy_1" {
+  name = "ike_policy_1"
+  pfs = "group5"
+  lifetime {
+    units = "seconds"
+    value = 3600
+  }
+}
+`
+
+const testAccVpnIKEPolicyV2_update = `
+resource "huaweicloud_vpnaas_ike_policy_v2" "policy_1" {
+  name = "ike_policy_1_updated"
+  pfs = "group14"
+  lifetime {
+    units = "seconds"
+    value = 7200
+  }
+}
+`
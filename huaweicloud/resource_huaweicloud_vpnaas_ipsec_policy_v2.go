@@ -0,0 +1,204 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceVpnIPSecPolicyV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpnIPSecPolicyV2Create,
+		Read:   resourceVpnIPSecPolicyV2Read,
+		Update: resourceVpnIPSecPolicyV2Update,
+		Delete: resourceVpnIPSecPolicyV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"auth_algorithm": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "sha1",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"md5", "sha1", "sha256", "sha384", "sha512"})
+				},
+			},
+			"encapsulation_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "tunnel",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"tunnel", "transport"})
+				},
+			},
+			"encryption_algorithm": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "aes-128",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"3des", "aes-128", "aes-192", "aes-256"})
+				},
+			},
+			"pfs": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "group5",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"group2", "group5", "group14"})
+				},
+			},
+			"transform_protocol": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "esp",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"esp", "ah", "ah-esp"})
+				},
+			},
+			"lifetime": vpnLifetimeSchema(),
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpnIPSecPolicyV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	createOpts := vpnIPSecPolicy{
+		Name:                d.Get("name").(string),
+		Description:         d.Get("description").(string),
+		AuthAlgorithm:       d.Get("auth_algorithm").(string),
+		EncapsulationMode:   d.Get("encapsulation_mode").(string),
+		EncryptionAlgorithm: d.Get("encryption_algorithm").(string),
+		PFS:                 d.Get("pfs").(string),
+		TransformProtocol:   d.Get("transform_protocol").(string),
+		Lifetime:            expandVpnLifetime(d),
+		TenantID:            d.Get("tenant_id").(string),
+	}
+	log.Printf("[DEBUG] Create IPSec policy: %#v", createOpts)
+
+	var policy vpnIPSecPolicy
+	if err := vpnIPSecPolicies.create(vpnClient, createOpts, &policy); err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud IPSec policy: %s", err)
+	}
+	log.Printf("[DEBUG] IPSec policy created: %#v", policy)
+	d.SetId(policy.ID)
+
+	return resourceVpnIPSecPolicyV2Read(d, meta)
+}
+
+func resourceVpnIPSecPolicyV2Read(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Retrieve information about IPSec policy: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var policy vpnIPSecPolicy
+	if err := vpnIPSecPolicies.get(vpnClient, d.Id(), &policy); err != nil {
+		return CheckDeleted(d, err, "IPSec policy")
+	}
+
+	log.Printf("[DEBUG] Read HuaweiCloud IPSec policy %s: %#v", d.Id(), policy)
+
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("auth_algorithm", policy.AuthAlgorithm)
+	d.Set("encapsulation_mode", policy.EncapsulationMode)
+	d.Set("encryption_algorithm", policy.EncryptionAlgorithm)
+	d.Set("pfs", policy.PFS)
+	d.Set("transform_protocol", policy.TransformProtocol)
+	if err := d.Set("lifetime", flattenVpnLifetime(policy.Lifetime)); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving lifetime to state for HuaweiCloud IPSec policy (%s): %s", d.Id(), err)
+	}
+	d.Set("tenant_id", policy.TenantID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpnIPSecPolicyV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var opts vpnIPSecPolicy
+	if d.HasChange("name") {
+		opts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts.Description = d.Get("description").(string)
+	}
+	if d.HasChange("auth_algorithm") {
+		opts.AuthAlgorithm = d.Get("auth_algorithm").(string)
+	}
+	if d.HasChange("encapsulation_mode") {
+		opts.EncapsulationMode = d.Get("encapsulation_mode").(string)
+	}
+	if d.HasChange("encryption_algorithm") {
+		opts.EncryptionAlgorithm = d.Get("encryption_algorithm").(string)
+	}
+	if d.HasChange("pfs") {
+		opts.PFS = d.Get("pfs").(string)
+	}
+	if d.HasChange("transform_protocol") {
+		opts.TransformProtocol = d.Get("transform_protocol").(string)
+	}
+	if d.HasChange("lifetime") {
+		opts.Lifetime = expandVpnLifetime(d)
+	}
+
+	log.Printf("[DEBUG] Updating IPSec policy with id %s: %#v", d.Id(), opts)
+	if err := vpnIPSecPolicies.update(vpnClient, d.Id(), opts); err != nil {
+		return fmt.Errorf("Error updating HuaweiCloud IPSec policy: %s", err)
+	}
+
+	return resourceVpnIPSecPolicyV2Read(d, meta)
+}
+
+func resourceVpnIPSecPolicyV2Delete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy IPSec policy: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	if err := vpnIPSecPolicies.delete(vpnClient, d.Id()); err != nil {
+		return CheckDeleted(d, err, "IPSec policy")
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,119 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func TestAccVpnIPSecPolicyV2_basic(t *testing.T) {
+	var v vpnIPSecPolicy
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnIPSecPolicyV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpnIPSecPolicyV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIPSecPolicyV2Exists("huaweicloud_vpnaas_ipsec_policy_v2.policy_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ipsec_policy_v2.policy_1", "name", "ipsec_policy_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ipsec_policy_v2.policy_1", "encryption_algorithm", "aes-128"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ipsec_policy_v2.policy_1", "lifetime.0.value", "3600"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpnIPSecPolicyV2_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnIPSecPolicyV2Exists("huaweicloud_vpnaas_ipsec_policy_v2.policy_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ipsec_policy_v2.policy_1", "name", "ipsec_policy_1_updated"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ipsec_policy_v2.policy_1", "encryption_algorithm", "aes-256"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_ipsec_policy_v2.policy_1", "lifetime.0.value", "7200"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpnIPSecPolicyV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_vpnaas_ipsec_policy_v2" {
+			continue
+		}
+		var found vpnIPSecPolicy
+		err = vpnIPSecPolicies.get(vpnClient, rs.Primary.ID, &found)
+		if err == nil {
+			return fmt.Errorf("IPSec policy (%s) still exists.", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccCheckVpnIPSecPolicyV2Exists(n string, found *vpnIPSecPolicy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+		}
+
+		if err := vpnIPSecPolicies.get(vpnClient, rs.Primary.ID, found); err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("IPSec policy not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccVpnIPSecPolicyV2_basic = `
+resource "huaweicloud_vpnaas_ipsec_policy_v2" "policy_1" {
+  name = "ipsec_policy_1"
+  encryption_algorithm = "aes-128"
+  lifetime {
+    units = "seconds"
+    value = 3600
+  }
+}
+`
+
+const testAccVpnIPSecPolicyV2_update = `
+resource "huaweicloud_vpnaas_ipsec_policy_v2" "policy_1" {
+  name = "ipsec_policy_1_updated"
+  encryption_algorithm = "aes-256"
+  lifetime {
+    units = "seconds"
+    value = 7200
+  }
+}
+`
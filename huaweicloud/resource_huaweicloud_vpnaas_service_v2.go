@@ -0,0 +1,193 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceVpnServiceV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpnServiceV2Create,
+		Read:   resourceVpnServiceV2Read,
+		Update: resourceVpnServiceV2Update,
+		Delete: resourceVpnServiceV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"admin_state_up": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"router_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"subnet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_v4_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_v6_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpnServiceV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	adminStateUp := d.Get("admin_state_up").(bool)
+	createOpts := vpnService{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		AdminStateUp: &adminStateUp,
+		RouterID:     d.Get("router_id").(string),
+		SubnetID:     d.Get("subnet_id").(string),
+		TenantID:     d.Get("tenant_id").(string),
+	}
+	log.Printf("[DEBUG] Create VPN service: %#v", createOpts)
+
+	var service vpnService
+	if err := vpnServices.create(vpnClient, createOpts, &service); err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud VPN service: %s", err)
+	}
+	log.Printf("[DEBUG] VPN service created: %#v", service)
+	d.SetId(service.ID)
+
+	if err := vpnServices.waitForVpnStatus(vpnClient, service.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error waiting for VPN service (%s) to become ready: %s", service.ID, err)
+	}
+
+	return resourceVpnServiceV2Read(d, meta)
+}
+
+func resourceVpnServiceV2Read(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Retrieve information about VPN service: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var service vpnService
+	if err := vpnServices.get(vpnClient, d.Id(), &service); err != nil {
+		return CheckDeleted(d, err, "VPN service")
+	}
+
+	log.Printf("[DEBUG] Read HuaweiCloud VPN service %s: %#v", d.Id(), service)
+
+	d.Set("name", service.Name)
+	d.Set("description", service.Description)
+	if service.AdminStateUp != nil {
+		d.Set("admin_state_up", *service.AdminStateUp)
+	}
+	d.Set("router_id", service.RouterID)
+	d.Set("subnet_id", service.SubnetID)
+	d.Set("tenant_id", service.TenantID)
+	d.Set("status", service.Status)
+	d.Set("external_v4_ip", service.ExternalV4IP)
+	d.Set("external_v6_ip", service.ExternalV6IP)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpnServiceV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var opts vpnService
+	if d.HasChange("name") {
+		opts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts.Description = d.Get("description").(string)
+	}
+	if d.HasChange("admin_state_up") {
+		adminStateUp := d.Get("admin_state_up").(bool)
+		opts.AdminStateUp = &adminStateUp
+	}
+
+	log.Printf("[DEBUG] Updating VPN service with id %s: %#v", d.Id(), opts)
+	if err := vpnServices.update(vpnClient, d.Id(), opts); err != nil {
+		return fmt.Errorf("Error updating HuaweiCloud VPN service: %s", err)
+	}
+
+	if err := vpnServices.waitForVpnStatus(vpnClient, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("Error waiting for VPN service (%s) to become ready: %s", d.Id(), err)
+	}
+
+	return resourceVpnServiceV2Read(d, meta)
+}
+
+func resourceVpnServiceV2Delete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy VPN service: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	if err := vpnServices.delete(vpnClient, d.Id()); err != nil {
+		return CheckDeleted(d, err, "VPN service")
+	}
+
+	if err := vpnServices.waitForDeletion(vpnClient, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("Error waiting for VPN service (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,155 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func TestAccVpnServiceV2_basic(t *testing.T) {
+	var v vpnService
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnServiceV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpnServiceV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnServiceV2Exists("huaweicloud_vpnaas_service_v2.service_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_service_v2.service_1", "name", "vpn_service_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_service_v2.service_1", "admin_state_up", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpnServiceV2_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnServiceV2Exists("huaweicloud_vpnaas_service_v2.service_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_service_v2.service_1", "name", "vpn_service_1_updated"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_service_v2.service_1", "admin_state_up", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpnServiceV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_vpnaas_service_v2" {
+			continue
+		}
+		var found vpnService
+		err = vpnServices.get(vpnClient, rs.Primary.ID, &found)
+		if err == nil {
+			return fmt.Errorf("VPN service (%s) still exists.", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccCheckVpnServiceV2Exists(n string, found *vpnService) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+		}
+
+		if err := vpnServices.get(vpnClient, rs.Primary.ID, found); err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("VPN service not found")
+		}
+
+		return nil
+	}
+}
+
+var testAccVpnServiceV2_basic = fmt.Sprintf(`
+resource "huaweicloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "huaweicloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+}
+
+resource "huaweicloud_networking_router_v2" "router_1" {
+  name = "router_1"
+  external_gateway = "%s"
+}
+
+resource "huaweicloud_networking_router_interface_v2" "router_interface_1" {
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  subnet_id = "${huaweicloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "huaweicloud_vpnaas_service_v2" "service_1" {
+  name = "vpn_service_1"
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  admin_state_up = "true"
+  depends_on = ["huaweicloud_networking_router_interface_v2.router_interface_1"]
+}
+`, OS_EXTGW_ID)
+
+var testAccVpnServiceV2_update = fmt.Sprintf(`
+resource "huaweicloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "huaweicloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+}
+
+resource "huaweicloud_networking_router_v2" "router_1" {
+  name = "router_1"
+  external_gateway = "%s"
+}
+
+resource "huaweicloud_networking_router_interface_v2" "router_interface_1" {
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  subnet_id = "${huaweicloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "huaweicloud_vpnaas_service_v2" "service_1" {
+  name = "vpn_service_1_updated"
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  admin_state_up = "false"
+  depends_on = ["huaweicloud_networking_router_interface_v2.router_interface_1"]
+}
+`, OS_EXTGW_ID)
@@ -0,0 +1,351 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceVpnSiteConnectionV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpnSiteConnectionV2Create,
+		Read:   resourceVpnSiteConnectionV2Read,
+		Update: resourceVpnSiteConnectionV2Update,
+		Delete: resourceVpnSiteConnectionV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vpnservice_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ikepolicy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ipsecpolicy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"peer_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"peer_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"local_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"local_ep_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"peer_ep_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"peer_cidrs": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"psk": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"mtu": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"initiator": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "bi-directional",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"bi-directional", "response-only"})
+				},
+			},
+			"dpd": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								return ValidateStringList(v, k, []string{"hold", "clear", "restart", "disabled", "restart-by-peer"})
+							},
+						},
+						"interval": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"timeout": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"admin_state_up": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpnSiteConnectionV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	adminStateUp := d.Get("admin_state_up").(bool)
+	createOpts := vpnSiteConnection{
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		VPNServiceID:   d.Get("vpnservice_id").(string),
+		IKEPolicyID:    d.Get("ikepolicy_id").(string),
+		IPSecPolicyID:  d.Get("ipsecpolicy_id").(string),
+		PeerAddress:    d.Get("peer_address").(string),
+		PeerID:         d.Get("peer_id").(string),
+		LocalID:        d.Get("local_id").(string),
+		LocalEPGroupID: d.Get("local_ep_group_id").(string),
+		PeerEPGroupID:  d.Get("peer_ep_group_id").(string),
+		PeerCIDRs:      resourceVpnSiteConnectionV2PeerCIDRs(d),
+		PSK:            d.Get("psk").(string),
+		MTU:            d.Get("mtu").(int),
+		Initiator:      d.Get("initiator").(string),
+		DPD:            resourceVpnSiteConnectionV2DPD(d),
+		AdminStateUp:   &adminStateUp,
+		TenantID:       d.Get("tenant_id").(string),
+	}
+	// Do not log the pre-shared key.
+	log.Printf("[DEBUG] Create IPSec site connection for VPN service %s", createOpts.VPNServiceID)
+
+	var conn vpnSiteConnection
+	if err := vpnSiteConnections.create(vpnClient, createOpts, &conn); err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud IPSec site connection: %s", err)
+	}
+	log.Printf("[DEBUG] IPSec site connection created: %s", conn.ID)
+	d.SetId(conn.ID)
+
+	if err := vpnSiteConnections.waitForVpnStatus(vpnClient, conn.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error waiting for IPSec site connection (%s) to become ready: %s", conn.ID, err)
+	}
+
+	return resourceVpnSiteConnectionV2Read(d, meta)
+}
+
+func resourceVpnSiteConnectionV2Read(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Retrieve information about IPSec site connection: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var conn vpnSiteConnection
+	if err := vpnSiteConnections.get(vpnClient, d.Id(), &conn); err != nil {
+		return CheckDeleted(d, err, "IPSec site connection")
+	}
+
+	log.Printf("[DEBUG] Read HuaweiCloud IPSec site connection %s", d.Id())
+
+	d.Set("name", conn.Name)
+	d.Set("description", conn.Description)
+	d.Set("vpnservice_id", conn.VPNServiceID)
+	d.Set("ikepolicy_id", conn.IKEPolicyID)
+	d.Set("ipsecpolicy_id", conn.IPSecPolicyID)
+	d.Set("peer_address", conn.PeerAddress)
+	d.Set("peer_id", conn.PeerID)
+	d.Set("local_id", conn.LocalID)
+	d.Set("local_ep_group_id", conn.LocalEPGroupID)
+	d.Set("peer_ep_group_id", conn.PeerEPGroupID)
+	if err := d.Set("peer_cidrs", conn.PeerCIDRs); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving peer_cidrs to state for HuaweiCloud IPSec site connection (%s): %s", d.Id(), err)
+	}
+	if conn.PSK != "" {
+		d.Set("psk", conn.PSK)
+	}
+	d.Set("mtu", conn.MTU)
+	d.Set("initiator", conn.Initiator)
+	if conn.DPD != nil {
+		dpd := []map[string]interface{}{
+			{
+				"action":   conn.DPD.Action,
+				"interval": conn.DPD.Interval,
+				"timeout":  conn.DPD.Timeout,
+			},
+		}
+		if err := d.Set("dpd", dpd); err != nil {
+			return fmt.Errorf("[DEBUG] Error saving dpd to state for HuaweiCloud IPSec site connection (%s): %s", d.Id(), err)
+		}
+	}
+	if conn.AdminStateUp != nil {
+		d.Set("admin_state_up", *conn.AdminStateUp)
+	}
+	d.Set("tenant_id", conn.TenantID)
+	d.Set("status", conn.Status)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpnSiteConnectionV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	var opts vpnSiteConnectionUpdate
+	if d.HasChange("name") {
+		opts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts.Description = d.Get("description").(string)
+	}
+	if d.HasChange("peer_address") {
+		opts.PeerAddress = d.Get("peer_address").(string)
+	}
+	if d.HasChange("peer_id") {
+		opts.PeerID = d.Get("peer_id").(string)
+	}
+	if d.HasChange("local_id") {
+		opts.LocalID = d.Get("local_id").(string)
+	}
+	if d.HasChange("local_ep_group_id") {
+		opts.LocalEPGroupID = d.Get("local_ep_group_id").(string)
+	}
+	if d.HasChange("peer_ep_group_id") {
+		opts.PeerEPGroupID = d.Get("peer_ep_group_id").(string)
+	}
+	if d.HasChange("peer_cidrs") {
+		peerCIDRs := resourceVpnSiteConnectionV2PeerCIDRs(d)
+		opts.PeerCIDRs = &peerCIDRs
+	}
+	if d.HasChange("psk") {
+		opts.PSK = d.Get("psk").(string)
+	}
+	if d.HasChange("mtu") {
+		opts.MTU = d.Get("mtu").(int)
+	}
+	if d.HasChange("initiator") {
+		opts.Initiator = d.Get("initiator").(string)
+	}
+	if d.HasChange("dpd") {
+		opts.DPD = resourceVpnSiteConnectionV2DPD(d)
+	}
+	if d.HasChange("admin_state_up") {
+		adminStateUp := d.Get("admin_state_up").(bool)
+		opts.AdminStateUp = &adminStateUp
+	}
+
+	log.Printf("[DEBUG] Updating IPSec site connection with id %s", d.Id())
+	if err := vpnSiteConnections.update(vpnClient, d.Id(), opts); err != nil {
+		return fmt.Errorf("Error updating HuaweiCloud IPSec site connection: %s", err)
+	}
+
+	if err := vpnSiteConnections.waitForVpnStatus(vpnClient, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("Error waiting for IPSec site connection (%s) to become ready: %s", d.Id(), err)
+	}
+
+	return resourceVpnSiteConnectionV2Read(d, meta)
+}
+
+func resourceVpnSiteConnectionV2Delete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Destroy IPSec site connection: %s", d.Id())
+
+	config := meta.(*Config)
+	vpnClient, err := config.vpnV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+
+	if err := vpnSiteConnections.delete(vpnClient, d.Id()); err != nil {
+		return CheckDeleted(d, err, "IPSec site connection")
+	}
+
+	if err := vpnSiteConnections.waitForDeletion(vpnClient, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("Error waiting for IPSec site connection (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceVpnSiteConnectionV2PeerCIDRs(d *schema.ResourceData) []string {
+	rawCIDRs := d.Get("peer_cidrs").([]interface{})
+	cidrs := make([]string, len(rawCIDRs))
+	for i, raw := range rawCIDRs {
+		cidrs[i] = raw.(string)
+	}
+	return cidrs
+}
+
+func resourceVpnSiteConnectionV2DPD(d *schema.ResourceData) *vpnDPD {
+	list := d.Get("dpd").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	return &vpnDPD{
+		Action:   m["action"].(string),
+		Interval: m["interval"].(int),
+		Timeout:  m["timeout"].(int),
+	}
+}
@@ -0,0 +1,248 @@
+package huaweicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func TestAccVpnSiteConnectionV2_basic(t *testing.T) {
+	var v vpnSiteConnection
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnSiteConnectionV2Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpnSiteConnectionV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnSiteConnectionV2Exists("huaweicloud_vpnaas_site_connection_v2.conn_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_site_connection_v2.conn_1", "name", "conn_1"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_site_connection_v2.conn_1", "mtu", "1500"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_site_connection_v2.conn_1", "dpd.0.action", "hold"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpnSiteConnectionV2_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnSiteConnectionV2Exists("huaweicloud_vpnaas_site_connection_v2.conn_1", &v),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_site_connection_v2.conn_1", "name", "conn_1_updated"),
+					resource.TestCheckResourceAttr(
+						"huaweicloud_vpnaas_site_connection_v2.conn_1", "mtu", "1400"),
+				),
+			},
+		},
+	})
+}
+
+func TestVpnSiteConnectionUpdate_clearPeerCIDRs(t *testing.T) {
+	peerCIDRs := []string{}
+	b, err := json.Marshal(vpnSiteConnectionUpdate{
+		vpnSiteConnection: vpnSiteConnection{Name: "conn_1"},
+		PeerCIDRs:         &peerCIDRs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"name":"conn_1","peer_cidrs":[]}`
+	if string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+}
+
+func testAccCheckVpnSiteConnectionV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_vpnaas_site_connection_v2" {
+			continue
+		}
+		var found vpnSiteConnection
+		err = vpnSiteConnections.get(vpnClient, rs.Primary.ID, &found)
+		if err == nil {
+			return fmt.Errorf("IPSec site connection (%s) still exists.", rs.Primary.ID)
+		}
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccCheckVpnSiteConnectionV2Exists(n string, found *vpnSiteConnection) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		vpnClient, err := config.vpnV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating HuaweiCloud vpn client: %s", err)
+		}
+
+		if err := vpnSiteConnections.get(vpnClient, rs.Primary.ID, found); err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("IPSec site connection not found")
+		}
+
+		return nil
+	}
+}
+
+var testAccVpnSiteConnectionV2_basic = fmt.Sprintf(`
+resource "huaweicloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "huaweicloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+}
+
+resource "huaweicloud_networking_router_v2" "router_1" {
+  name = "router_1"
+  external_gateway = "%s"
+}
+
+resource "huaweicloud_networking_router_interface_v2" "router_interface_1" {
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  subnet_id = "${huaweicloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "huaweicloud_vpnaas_service_v2" "service_1" {
+  name = "vpn_service_1"
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  depends_on = ["huaweicloud_networking_router_interface_v2.router_interface_1"]
+}
+
+resource "huaweicloud_vpnaas_ike_policy_v2" "policy_1" {
+  name = "ike_policy_1"
+}
+
+resource "huaweicloud_vpnaas_ipsec_policy_v2" "policy_1" {
+  name = "ipsec_policy_1"
+}
+
+resource "huaweicloud_vpnaas_endpoint_group_v2" "group_1" {
+  name = "local_group"
+  type = "subnet"
+  endpoints = ["${huaweicloud_networking_subnet_v2.subnet_1.id}"]
+}
+
+resource "huaweicloud_vpnaas_endpoint_group_v2" "group_2" {
+  name = "peer_group"
+  type = "cidr"
+  endpoints = ["10.2.0.0/24"]
+}
+
+resource "huaweicloud_vpnaas_site_connection_v2" "conn_1" {
+  name = "conn_1"
+  vpnservice_id = "${huaweicloud_vpnaas_service_v2.service_1.id}"
+  ikepolicy_id = "${huaweicloud_vpnaas_ike_policy_v2.policy_1.id}"
+  ipsecpolicy_id = "${huaweicloud_vpnaas_ipsec_policy_v2.policy_1.id}"
+  local_ep_group_id = "${huaweicloud_vpnaas_endpoint_group_v2.group_1.id}"
+  peer_ep_group_id = "${huaweicloud_vpnaas_endpoint_group_v2.group_2.id}"
+  peer_address = "192.0.2.10"
+  peer_id = "192.0.2.10"
+  psk = "secret"
+  mtu = 1500
+  dpd {
+    action = "hold"
+    interval = 30
+    timeout = 120
+  }
+}
+`, OS_EXTGW_ID)
+
+var testAccVpnSiteConnectionV2_update = fmt.Sprintf(`
+resource "huaweicloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "huaweicloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${huaweicloud_networking_network_v2.network_1.id}"
+}
+
+resource "huaweicloud_networking_router_v2" "router_1" {
+  name = "router_1"
+  external_gateway = "%s"
+}
+
+resource "huaweicloud_networking_router_interface_v2" "router_interface_1" {
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  subnet_id = "${huaweicloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "huaweicloud_vpnaas_service_v2" "service_1" {
+  name = "vpn_service_1"
+  router_id = "${huaweicloud_networking_router_v2.router_1.id}"
+  depends_on = ["huaweicloud_networking_router_interface_v2.router_interface_1"]
+}
+
+resource "huaweicloud_vpnaas_ike_policy_v2" "policy_1" {
+  name = "ike_policy_1"
+}
+
+resource "huaweicloud_vpnaas_ipsec_policy_v2" "policy_1" {
+  name = "ipsec_policy_1"
+}
+
+resource "huaweicloud_vpnaas_endpoint_group_v2" "group_1" {
+  name = "local_group"
+  type = "subnet"
+  endpoints = ["${huaweicloud_networking_subnet_v2.subnet_1.id}"]
+}
+
+resource "huaweicloud_vpnaas_endpoint_group_v2" "group_2" {
+  name = "peer_group"
+  type = "cidr"
+  endpoints = ["10.2.0.0/24"]
+}
+
+resource "huaweicloud_vpnaas_site_connection_v2" "conn_1" {
+  name = "conn_1_updated"
+  vpnservice_id = "${huaweicloud_vpnaas_service_v2.service_1.id}"
+  ikepolicy_id = "${huaweicloud_vpnaas_ike_policy_v2.policy_1.id}"
+  ipsecpolicy_id = "${huaweicloud_vpnaas_ipsec_policy_v2.policy_1.id}"
+  local_ep_group_id = "${huaweicloud_vpnaas_endpoint_group_v2.group_1.id}"
+  peer_ep_group_id = "${huaweicloud_vpnaas_endpoint_group_v2.group_2.id}"
+  peer_address = "192.0.2.10"
+  peer_id = "192.0.2.10"
+  psk = "secret"
+  mtu = 1400
+  dpd {
+    action = "hold"
+    interval = 30
+    timeout = 120
+  }
+}
+`, OS_EXTGW_ID)
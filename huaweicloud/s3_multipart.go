@@ -0,0 +1,322 @@
+package huaweicloud
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	s3MultipartMinPartSize = 5 * 1024 * 1024
+	s3MultipartMaxParts    = 10000
+
+	// s3MultipartMarkerPart is the number of the part which records the
+	// parameters an upload was initiated with, it is left out of the object.
+	s3MultipartMarkerPart = s3MultipartMaxParts
+)
+
+// s3MultipartPartSize returns the size of the parts source is uploaded in:
+// partSize, grown if needed so that the upload fits in the parts before the
+// marker part.
+func s3MultipartPartSize(size, partSize int64) int64 {
+	if partSize < s3MultipartMinPartSize {
+		partSize = s3MultipartMinPartSize
+	}
+	if min := (size + s3MultipartMarkerPart - 2) / (s3MultipartMarkerPart - 1); partSize < min {
+		partSize = min
+	}
+	return partSize
+}
+
+// s3MultipartParts returns the number of parts of size partSize of an
+// object of the given size, an empty object is still uploaded as one part.
+func s3MultipartParts(size, partSize int64) int64 {
+	if size == 0 {
+		return 1
+	}
+	return (size + partSize - 1) / partSize
+}
+
+// s3ObjectETags returns the MD5 of r and the ETag OBS computes for it when
+// it is uploaded in parts of partSize: the MD5 of the concatenated MD5s of
+// the parts followed by the number of parts, "md5-of-md5s-N".
+func s3ObjectETags(r io.ReaderAt, size, partSize int64) (string, string, error) {
+	whole := md5.New()
+	parts := md5.New()
+	n := s3MultipartParts(size, partSize)
+	for i := int64(0); i < n; i++ {
+		part := md5.New()
+		offset := i * partSize
+		length := partSize
+		if offset+length > size {
+			length = size - offset
+		}
+		if _, err := io.Copy(io.MultiWriter(whole, part), io.NewSectionReader(r, offset, length)); err != nil {
+			return "", "", err
+		}
+		parts.Write(part.Sum(nil))
+	}
+
+	return hex.EncodeToString(whole.Sum(nil)),
+		fmt.Sprintf("%s-%d", hex.EncodeToString(parts.Sum(nil)), n), nil
+}
+
+// isS3MultipartETag reports whether etag is the ETag of an object uploaded
+// in parts.
+func isS3MultipartETag(etag string) bool {
+	return strings.Contains(etag, "-")
+}
+
+// s3MultipartUpload uploads an object in parts of PartSize, Concurrency of
+// them at a time. An upload left unfinished by a previous attempt for the
+// same key and with the same Input is resumed, the parts already uploaded
+// with the same content are not sent again.
+type s3MultipartUpload struct {
+	Conn        *s3.S3
+	Input       *s3.CreateMultipartUploadInput
+	Body        io.ReaderAt
+	Size        int64
+	PartSize    int64
+	Concurrency int
+}
+
+func (u *s3MultipartUpload) Upload() (*s3.CompleteMultipartUploadOutput, error) {
+	bucket, key := aws.StringValue(u.Input.Bucket), aws.StringValue(u.Input.Key)
+	n := s3MultipartParts(u.Size, u.PartSize)
+
+	uploadID, uploaded, err := u.pending()
+	if err != nil {
+		return nil, err
+	}
+	if uploadID == "" {
+		out, err := u.Conn.CreateMultipartUpload(u.Input)
+		if err != nil {
+			return nil, fmt.Errorf("Error initiating multipart upload of %s/%s: %s", bucket, key, err)
+		}
+		uploadID = aws.StringValue(out.UploadId)
+		log.Printf("[DEBUG] Initiated multipart upload %s of %s/%s in %d parts", uploadID, bucket, key, n)
+
+		_, err = u.Conn.UploadPart(&s3.UploadPartInput{
+			Bucket:     u.Input.Bucket,
+			Key:        u.Input.Key,
+			UploadId:   aws.String(uploadID),
+			PartNumber: aws.Int64(s3MultipartMarkerPart),
+			Body:       bytes.NewReader(u.marker()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error uploading the marker part of %s/%s (upload %s): %s", bucket, key, uploadID, err)
+		}
+	} else {
+		log.Printf("[DEBUG] Resuming multipart upload %s of %s/%s, %d of %d parts uploaded", uploadID, bucket, key, len(uploaded), n)
+	}
+
+	completed := make([]*s3.CompletedPart, n)
+	todo := make(chan int64, n)
+	for i := int64(1); i <= n; i++ {
+		offset, length := u.part(i)
+		if p, ok := uploaded[i]; ok && aws.Int64Value(p.Size) == length {
+			sum, err := u.partMD5(offset, length)
+			if err != nil {
+				return nil, err
+			}
+			if strings.Trim(aws.StringValue(p.ETag), `"`) == sum {
+				completed[i-1] = &s3.CompletedPart{ETag: p.ETag, PartNumber: aws.Int64(i)}
+				continue
+			}
+		}
+		todo <- i
+	}
+	close(todo)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []string
+	concurrency := u.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range todo {
+				offset, length := u.part(i)
+				out, err := u.Conn.UploadPart(&s3.UploadPartInput{
+					Bucket:        u.Input.Bucket,
+					Key:           u.Input.Key,
+					UploadId:      aws.String(uploadID),
+					PartNumber:    aws.Int64(i),
+					ContentLength: aws.Int64(length),
+					Body:          io.NewSectionReader(u.Body, offset, length),
+				})
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("part %d: %s", i, err))
+				} else {
+					completed[i-1] = &s3.CompletedPart{ETag: out.ETag, PartNumber: aws.Int64(i)}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The upload is left in place on failure so that the next attempt can
+	// resume it, abort_incomplete_multipart_upload_days cleans up the
+	// abandoned ones.
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, fmt.Errorf("Error uploading parts of %s/%s (upload %s): %s", bucket, key, uploadID, strings.Join(errs, "; "))
+	}
+
+	out, err := u.Conn.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          u.Input.Bucket,
+		Key:             u.Input.Key,
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error completing multipart upload %s of %s/%s: %s", uploadID, bucket, key, err)
+	}
+	return out, nil
+}
+
+// part returns the offset and length of the part numbered i, from 1.
+func (u *s3MultipartUpload) part(i int64) (int64, int64) {
+	offset := (i - 1) * u.PartSize
+	length := u.PartSize
+	if offset+length > u.Size {
+		length = u.Size - offset
+	}
+	return offset, length
+}
+
+func (u *s3MultipartUpload) partMD5(offset, length int64) (string, error) {
+	h := md5.New()
+	if _, err := io.Copy(h, io.NewSectionReader(u.Body, offset, length)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// marker returns the content of the marker part: OBS doesn't return the
+// parameters of an unfinished upload, but the ETag of the marker part tells
+// whether they are those of Input.
+func (u *s3MultipartUpload) marker() []byte {
+	in := u.Input
+	return []byte(strings.Join([]string{
+		"terraform-provider-huaweicloud multipart upload",
+		aws.StringValue(in.ACL),
+		aws.StringValue(in.CacheControl),
+		aws.StringValue(in.ContentType),
+		aws.StringValue(in.ContentEncoding),
+		aws.StringValue(in.ContentLanguage),
+		aws.StringValue(in.ContentDisposition),
+		aws.StringValue(in.ServerSideEncryption),
+		aws.StringValue(in.SSEKMSKeyId),
+		aws.StringValue(in.WebsiteRedirectLocation),
+	}, "\n"))
+}
+
+// pending returns the most recent unfinished upload of the key initiated
+// with the same Input, and its uploaded parts. The other unfinished uploads
+// of the key are aborted, completing them would give the object the
+// parameters they were initiated with.
+func (u *s3MultipartUpload) pending() (string, map[int64]*s3.Part, error) {
+	var uploads []*s3.MultipartUpload
+	err := u.Conn.ListMultipartUploadsPages(&s3.ListMultipartUploadsInput{
+		Bucket: u.Input.Bucket,
+		Prefix: u.Input.Key,
+	}, func(page *s3.ListMultipartUploadsOutput, lastPage bool) bool {
+		for _, upload := range page.Uploads {
+			if aws.StringValue(upload.Key) == aws.StringValue(u.Input.Key) {
+				uploads = append(uploads, upload)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("Error listing multipart uploads of %s: %s", aws.StringValue(u.Input.Bucket), err)
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		return aws.TimeValue(uploads[i].Initiated).After(aws.TimeValue(uploads[j].Initiated))
+	})
+
+	sum := md5.Sum(u.marker())
+	markerETag := hex.EncodeToString(sum[:])
+
+	var uploadID string
+	var parts map[int64]*s3.Part
+	for _, upload := range uploads {
+		if uploadID == "" {
+			p, err := u.parts(aws.StringValue(upload.UploadId))
+			if err != nil {
+				return "", nil, err
+			}
+			if m, ok := p[s3MultipartMarkerPart]; ok && strings.Trim(aws.StringValue(m.ETag), `"`) == markerETag {
+				delete(p, s3MultipartMarkerPart)
+				uploadID, parts = aws.StringValue(upload.UploadId), p
+				continue
+			}
+		}
+
+		log.Printf("[DEBUG] Aborting multipart upload %s of %s/%s", aws.StringValue(upload.UploadId),
+			aws.StringValue(u.Input.Bucket), aws.StringValue(u.Input.Key))
+		_, err := u.Conn.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+			Bucket:   u.Input.Bucket,
+			Key:      u.Input.Key,
+			UploadId: upload.UploadId,
+		})
+		if awsErr, ok := err.(awserr.Error); err != nil && (!ok || awsErr.Code() != s3.ErrCodeNoSuchUpload) {
+			return "", nil, fmt.Errorf("Error aborting multipart upload %s: %s", aws.StringValue(upload.UploadId), err)
+		}
+	}
+	return uploadID, parts, nil
+}
+
+// parts returns the uploaded parts of an upload by number.
+func (u *s3MultipartUpload) parts(uploadID string) (map[int64]*s3.Part, error) {
+	parts := make(map[int64]*s3.Part)
+	err := u.Conn.ListPartsPages(&s3.ListPartsInput{
+		Bucket:   u.Input.Bucket,
+		Key:      u.Input.Key,
+		UploadId: aws.String(uploadID),
+	}, func(page *s3.ListPartsOutput, lastPage bool) bool {
+		for _, p := range page.Parts {
+			parts[aws.Int64Value(p.PartNumber)] = p
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing the parts of multipart upload %s: %s", uploadID, err)
+	}
+	return parts, nil
+}
+
+// s3CreateMultipartUploadInput returns the multipart counterpart of put.
+func s3CreateMultipartUploadInput(put *s3.PutObjectInput) *s3.CreateMultipartUploadInput {
+	return &s3.CreateMultipartUploadInput{
+		Bucket:                  put.Bucket,
+		Key:                     put.Key,
+		ACL:                     put.ACL,
+		CacheControl:            put.CacheControl,
+		ContentType:             put.ContentType,
+		ContentEncoding:         put.ContentEncoding,
+		ContentLanguage:         put.ContentLanguage,
+		ContentDisposition:      put.ContentDisposition,
+		ServerSideEncryption:    put.ServerSideEncryption,
+		SSEKMSKeyId:             put.SSEKMSKeyId,
+		WebsiteRedirectLocation: put.WebsiteRedirectLocation,
+	}
+}
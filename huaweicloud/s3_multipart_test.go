@@ -0,0 +1,222 @@
+package huaweicloud
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestS3MultipartPartSize(t *testing.T) {
+	cases := []struct {
+		Size, PartSize, Expected int64
+	}{
+		{100 * 1024 * 1024, 16 * 1024 * 1024, 16 * 1024 * 1024},
+		{100 * 1024 * 1024, 1024, s3MultipartMinPartSize},
+		// 100 GiB does not fit in the 9999 parts before the marker part.
+		{100 * 1024 * 1024 * 1024, s3MultipartMinPartSize, 10738493},
+	}
+
+	for _, tc := range cases {
+		if v := s3MultipartPartSize(tc.Size, tc.PartSize); v != tc.Expected {
+			t.Fatalf("s3MultipartPartSize(%d, %d) = %d, expected %d", tc.Size, tc.PartSize, v, tc.Expected)
+		}
+		if n := s3MultipartParts(tc.Size, s3MultipartPartSize(tc.Size, tc.PartSize)); n >= s3MultipartMarkerPart {
+			t.Fatalf("%d bytes are split in %d parts", tc.Size, n)
+		}
+	}
+}
+
+func TestS3ObjectETags(t *testing.T) {
+	body := []byte("abcdefghij")
+
+	var parts []byte
+	for _, p := range []string{"abcd", "efgh", "ij"} {
+		sum := md5.Sum([]byte(p))
+		parts = append(parts, sum[:]...)
+	}
+	partsSum := md5.Sum(parts)
+	wholeSum := md5.Sum(body)
+
+	sum, etag, err := s3ObjectETags(bytes.NewReader(body), int64(len(body)), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := hex.EncodeToString(wholeSum[:]); sum != expected {
+		t.Fatalf("expected MD5 %s, got %s", expected, sum)
+	}
+	if expected := hex.EncodeToString(partsSum[:]) + "-3"; etag != expected {
+		t.Fatalf("expected multipart ETag %s, got %s", expected, etag)
+	}
+	if !isS3MultipartETag(etag) || isS3MultipartETag(sum) {
+		t.Fatalf("isS3MultipartETag does not tell %s from %s", etag, sum)
+	}
+}
+
+func TestS3BucketObjectETag(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "tf-s3-obj-etag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	body := []byte("abcdefghij")
+	if _, err := tmpFile.Write(body); err != nil {
+		t.Fatal(err)
+	}
+	tmpFile.Close()
+
+	sum, multipartETag, err := s3ObjectETags(bytes.NewReader(body), int64(len(body)), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The part size used by the upload wins over multipart_part_size.
+	d := schema.TestResourceDataRaw(t, resourceS3BucketObject().Schema, map[string]interface{}{
+		"bucket":              "bucket",
+		"key":                 "key",
+		"source":              tmpFile.Name(),
+		"multipart_part_size": 16,
+	})
+	d.Set("multipart_upload_part_size", 4)
+	if etag := s3BucketObjectETag(d, multipartETag); etag != sum {
+		t.Fatalf("expected %s, got %s", sum, etag)
+	}
+
+	// An ETag mapped before is not hashed again, source is gone by now.
+	os.Remove(tmpFile.Name())
+	d.Set("etag", sum)
+	d.Set("multipart_etag", multipartETag)
+	if etag := s3BucketObjectETag(d, multipartETag); etag != sum {
+		t.Fatalf("expected %s, got %s", sum, etag)
+	}
+	if etag := s3BucketObjectETag(d, "0123456789abcdef0123456789abcdef-2"); etag == sum {
+		t.Fatalf("a changed ETag is mapped to %s", sum)
+	}
+}
+
+// fakeS3MultipartServer serves the multipart upload calls for one key.
+type fakeS3MultipartServer struct {
+	mu      sync.Mutex
+	uploads map[string]map[int64][]byte
+	aborted []string
+	created int
+	sent    []int64
+}
+
+func (f *fakeS3MultipartServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q := r.URL.Query()
+	uploadID := q.Get("uploadId")
+	_, uploads := q["uploads"]
+	switch {
+	case r.Method == "GET" && uploads:
+		fmt.Fprint(w, `<ListMultipartUploadsResult><IsTruncated>false</IsTruncated>`)
+		for id := range f.uploads {
+			fmt.Fprintf(w, `<Upload><Key>key</Key><UploadId>%s</UploadId><Initiated>2019-01-01T00:00:00Z</Initiated></Upload>`, id)
+		}
+		fmt.Fprint(w, `</ListMultipartUploadsResult>`)
+	case r.Method == "GET":
+		fmt.Fprint(w, `<ListPartsResult><IsTruncated>false</IsTruncated>`)
+		for n, b := range f.uploads[uploadID] {
+			sum := md5.Sum(b)
+			fmt.Fprintf(w, `<Part><PartNumber>%d</PartNumber><ETag>"%s"</ETag><Size>%d</Size></Part>`,
+				n, hex.EncodeToString(sum[:]), len(b))
+		}
+		fmt.Fprint(w, `</ListPartsResult>`)
+	case r.Method == "DELETE":
+		delete(f.uploads, uploadID)
+		f.aborted = append(f.aborted, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "POST" && uploadID == "":
+		f.created++
+		f.uploads["new-upload"] = make(map[int64][]byte)
+		fmt.Fprint(w, `<InitiateMultipartUploadResult><UploadId>new-upload</UploadId></InitiateMultipartUploadResult>`)
+	case r.Method == "PUT":
+		n, _ := strconv.ParseInt(q.Get("partNumber"), 10, 64)
+		b, _ := ioutil.ReadAll(r.Body)
+		f.uploads[uploadID][n] = b
+		f.sent = append(f.sent, n)
+		sum := md5.Sum(b)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	case r.Method == "POST":
+		fmt.Fprint(w, `<CompleteMultipartUploadResult><ETag>"etag-3"</ETag></CompleteMultipartUploadResult>`)
+	}
+}
+
+func TestS3MultipartUpload_resume(t *testing.T) {
+	body := []byte("abcdefghij")
+	newUpload := func(conn *s3.S3, acl string) *s3MultipartUpload {
+		return &s3MultipartUpload{
+			Conn: conn,
+			Input: &s3.CreateMultipartUploadInput{
+				Bucket: aws.String("bucket"),
+				Key:    aws.String("key"),
+				ACL:    aws.String(acl),
+			},
+			Body:        bytes.NewReader(body),
+			Size:        int64(len(body)),
+			PartSize:    4,
+			Concurrency: 2,
+		}
+	}
+
+	fake := &fakeS3MultipartServer{uploads: make(map[string]map[int64][]byte)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	conn := s3.New(session.New(&aws.Config{
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("cn-north-1"),
+		S3ForcePathStyle: aws.Bool(true),
+		Credentials:      credentials.NewStaticCredentials("ak", "sk", ""),
+	}))
+
+	// The upload left by a previous attempt with the same ACL is resumed,
+	// the one initiated by another client is aborted.
+	fake.uploads["ours"] = map[int64][]byte{
+		1:                     []byte("abcd"),
+		s3MultipartMarkerPart: newUpload(conn, "private").marker(),
+	}
+	fake.uploads["another-client"] = map[int64][]byte{1: []byte("abcd")}
+	if _, err := newUpload(conn, "private").Upload(); err != nil {
+		t.Fatal(err)
+	}
+	if fake.created != 0 || !reflect.DeepEqual(fake.aborted, []string{"another-client"}) {
+		t.Fatalf("expected ours to be resumed, created %d, aborted %v", fake.created, fake.aborted)
+	}
+	sort.Slice(fake.sent, func(i, j int) bool { return fake.sent[i] < fake.sent[j] })
+	if !reflect.DeepEqual(fake.sent, []int64{2, 3}) {
+		t.Fatalf("expected parts 2 and 3 to be sent, got %v", fake.sent)
+	}
+
+	// An upload initiated with another ACL is aborted and started over.
+	fake.aborted, fake.sent = nil, nil
+	if _, err := newUpload(conn, "public-read").Upload(); err != nil {
+		t.Fatal(err)
+	}
+	if fake.created != 1 || !reflect.DeepEqual(fake.aborted, []string{"ours"}) {
+		t.Fatalf("expected ours to be aborted, created %d, aborted %v", fake.created, fake.aborted)
+	}
+	sort.Slice(fake.sent, func(i, j int) bool { return fake.sent[i] < fake.sent[j] })
+	if !reflect.DeepEqual(fake.sent, []int64{1, 2, 3, s3MultipartMarkerPart}) {
+		t.Fatalf("expected every part and the marker to be sent, got %v", fake.sent)
+	}
+}
@@ -0,0 +1,201 @@
+package huaweicloud
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/sfs/v2/shares"
+)
+
+// sfsAccessRule is an access rule of a share, ID is empty until it is granted.
+type sfsAccessRule struct {
+	ID          string
+	AccessTo    string
+	AccessType  string
+	AccessLevel string
+}
+
+// key identifies what a rule grants, regardless of its ID.
+func (r sfsAccessRule) key() string {
+	return fmt.Sprintf("%s/%s/%s", r.AccessType, r.AccessTo, r.AccessLevel)
+}
+
+func resourceSFSAccessRuleHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["access_to"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["access_level"].(string)))
+	if v, ok := m["access_type"]; ok && v.(string) != "" {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	} else {
+		buf.WriteString("cert-")
+	}
+	return hashcode.String(buf.String())
+}
+
+func validateSFSAccessLevel(v interface{}, k string) (ws []string, errors []error) {
+	return ValidateStringList(v, k, []string{"rw", "ro"})
+}
+
+func expandSFSAccessRules(s *schema.Set) []sfsAccessRule {
+	rules := make([]sfsAccessRule, 0, s.Len())
+	for _, v := range s.List() {
+		m := v.(map[string]interface{})
+		rule := sfsAccessRule{
+			AccessTo:    m["access_to"].(string),
+			AccessType:  m["access_type"].(string),
+			AccessLevel: m["access_level"].(string),
+		}
+		if id, ok := m["access_rule_id"]; ok {
+			rule.ID = id.(string)
+		}
+		if rule.AccessType == "" {
+			rule.AccessType = "cert"
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func flattenSFSAccessRules(rules []shares.AccessRight) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, map[string]interface{}{
+			"access_to":      rule.AccessTo,
+			"access_type":    rule.AccessType,
+			"access_level":   rule.AccessLevel,
+			"access_rule_id": rule.ID,
+			"status":         rule.State,
+		})
+	}
+	return result
+}
+
+// legacySFSAccessRule returns the rule of the deprecated access_* fields.
+func legacySFSAccessRule(d *schema.ResourceData) (sfsAccessRule, bool) {
+	rule := sfsAccessRule{
+		AccessTo:    d.Get("access_to").(string),
+		AccessType:  d.Get("access_type").(string),
+		AccessLevel: d.Get("access_level").(string),
+	}
+	return rule, rule.AccessTo != ""
+}
+
+// diffSFSAccessRules plans the move from the old rules to the new ones
+// without cutting clients off: the rules for new targets are granted before
+// anything is revoked, only the rules which change the access level or type
+// of a target are granted after the old rule of that target is revoked.
+func diffSFSAccessRules(old, new []sfsAccessRule) (grant, revoke, regrant []sfsAccessRule) {
+	oldKeys := make(map[string]bool)
+	oldTargets := make(map[string]bool)
+	for _, r := range old {
+		oldKeys[r.key()] = true
+		oldTargets[r.AccessTo] = true
+	}
+	newKeys := make(map[string]bool)
+	for _, r := range new {
+		newKeys[r.key()] = true
+	}
+
+	for _, r := range old {
+		if !newKeys[r.key()] {
+			revoke = append(revoke, r)
+		}
+	}
+	for _, r := range new {
+		if oldKeys[r.key()] {
+			continue
+		}
+		if oldTargets[r.AccessTo] {
+			regrant = append(regrant, r)
+		} else {
+			grant = append(grant, r)
+		}
+	}
+	return
+}
+
+func updateSFSAccessRules(ctx context.Context, client *golangsdk.ServiceClient, shareID string, old, new []sfsAccessRule) error {
+	grant, revoke, regrant := diffSFSAccessRules(old, new)
+
+	for _, rule := range grant {
+		if _, err := grantSFSAccessRule(ctx, client, shareID, rule); err != nil {
+			return err
+		}
+	}
+	for _, rule := range revoke {
+		if err := revokeSFSAccessRule(client, shareID, rule.ID); err != nil {
+			return err
+		}
+	}
+	for _, rule := range regrant {
+		if _, err := grantSFSAccessRule(ctx, client, shareID, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantSFSAccessRule grants rule on the share and waits for it to be active.
+func grantSFSAccessRule(ctx context.Context, client *golangsdk.ServiceClient, shareID string, rule sfsAccessRule) (*shares.AccessRight, error) {
+	log.Printf("[DEBUG] Granting access to share %s: %#v", shareID, rule)
+	grantAccessOpts := shares.GrantAccessOpts{
+		AccessLevel: rule.AccessLevel,
+		AccessType:  rule.AccessType,
+		AccessTo:    rule.AccessTo,
+	}
+	grant, err := shares.GrantAccess(client, shareID, grantAccessOpts).ExtractAccess()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := waitForStatus(ctx, fmt.Sprintf("SFS access rule %s", grant.ID),
+		[]string{"new", "queued_to_apply", "applying"}, []string{"active"},
+		getSFSAccessRuleState(client, shareID, grant.ID))
+	if err != nil {
+		return nil, err
+	}
+	return r.(*shares.AccessRight), nil
+}
+
+func revokeSFSAccessRule(client *golangsdk.ServiceClient, shareID, ruleID string) error {
+	log.Printf("[DEBUG] Revoking access rule %s of share %s", ruleID, shareID)
+	deleteAccessOpts := shares.DeleteAccessOpts{AccessID: ruleID}
+	err := shares.DeleteAccess(client, shareID, deleteAccessOpts).Err
+	if _, ok := err.(golangsdk.ErrDefault404); ok {
+		return nil
+	}
+	return err
+}
+
+// getSFSAccessRule returns the access rule of the share, nil if there is none.
+func getSFSAccessRule(client *golangsdk.ServiceClient, shareID, ruleID string) (*shares.AccessRight, error) {
+	rules, err := shares.ListAccessRights(client, shareID).ExtractAccessRights()
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.ID == ruleID {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+func getSFSAccessRuleState(client *golangsdk.ServiceClient, shareID, ruleID string) func() (interface{}, string, error) {
+	return func() (interface{}, string, error) {
+		rule, err := getSFSAccessRule(client, shareID, ruleID)
+		if err != nil {
+			return nil, "", err
+		}
+		if rule == nil {
+			return nil, "", fmt.Errorf("access rule %s of share %s not found", ruleID, shareID)
+		}
+		return rule, rule.State, nil
+	}
+}
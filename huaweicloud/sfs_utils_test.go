@@ -0,0 +1,35 @@
+package huaweicloud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSFSAccessRules(t *testing.T) {
+	old := []sfsAccessRule{
+		{ID: "1", AccessTo: "vpc-a", AccessType: "cert", AccessLevel: "rw"},
+		{ID: "2", AccessTo: "vpc-b", AccessType: "cert", AccessLevel: "rw"},
+		{ID: "3", AccessTo: "vpc-c", AccessType: "cert", AccessLevel: "rw"},
+	}
+	new := []sfsAccessRule{
+		{AccessTo: "vpc-a", AccessType: "cert", AccessLevel: "rw"},
+		{AccessTo: "vpc-b", AccessType: "cert", AccessLevel: "ro"},
+		{AccessTo: "vpc-d", AccessType: "cert", AccessLevel: "rw"},
+	}
+
+	grant, revoke, regrant := diffSFSAccessRules(old, new)
+	if expected := new[2:]; !reflect.DeepEqual(grant, expected) {
+		t.Fatalf("expected grants %v, got %v", expected, grant)
+	}
+	if expected := old[1:]; !reflect.DeepEqual(revoke, expected) {
+		t.Fatalf("expected revokes %v, got %v", expected, revoke)
+	}
+	if expected := new[1:2]; !reflect.DeepEqual(regrant, expected) {
+		t.Fatalf("expected regrants %v, got %v", expected, regrant)
+	}
+
+	grant, revoke, regrant = diffSFSAccessRules(old, old)
+	if len(grant) != 0 || len(revoke) != 0 || len(regrant) != 0 {
+		t.Fatalf("expected no changes, got %v, %v, %v", grant, revoke, regrant)
+	}
+}
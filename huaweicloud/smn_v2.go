@@ -0,0 +1,132 @@
+package huaweicloud
+
+import (
+	"fmt"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/smn/v2/subscriptions"
+	"github.com/huaweicloud/golangsdk/openstack/smn/v2/topics"
+)
+
+// smnTopicAttributes are the topic attributes managed by the topic resource,
+// an empty value removes the attribute.
+var smnTopicAttributes = []string{"access_policy", "introduction"}
+
+func smnTopicAttributesURL(c *golangsdk.ServiceClient, urn string, name ...string) string {
+	return c.ServiceURL(append([]string{"topics", urn, "attributes"}, name...)...)
+}
+
+func getSMNTopicAttributes(c *golangsdk.ServiceClient, urn string) (map[string]string, error) {
+	var r struct {
+		Attributes map[string]string `json:"attributes"`
+	}
+	_, err := c.Get(smnTopicAttributesURL(c, urn), &r, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: topics.RequestOpts.MoreHeaders,
+	})
+	return r.Attributes, err
+}
+
+func updateSMNTopicAttribute(c *golangsdk.ServiceClient, urn, name, value string) error {
+	if value == "" {
+		_, err := c.Delete(smnTopicAttributesURL(c, urn, name), &golangsdk.RequestOpts{
+			OkCodes:     []int{200},
+			MoreHeaders: topics.RequestOpts.MoreHeaders,
+		})
+		return err
+	}
+
+	_, err := c.Put(smnTopicAttributesURL(c, urn, name), map[string]string{"value": value}, nil, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: topics.RequestOpts.MoreHeaders,
+	})
+	return err
+}
+
+// smnMessageTemplate is a message template of a protocol, the template
+// named "default" of a protocol is used when a message names no template.
+type smnMessageTemplate struct {
+	ID         string   `json:"message_template_id,omitempty"`
+	Name       string   `json:"message_template_name"`
+	Protocol   string   `json:"protocol"`
+	Content    string   `json:"content"`
+	TagNames   []string `json:"tag_names,omitempty"`
+	CreateTime string   `json:"create_time,omitempty"`
+	UpdateTime string   `json:"update_time,omitempty"`
+}
+
+func smnMessageTemplateURL(c *golangsdk.ServiceClient, id ...string) string {
+	return c.ServiceURL(append([]string{"message_template"}, id...)...)
+}
+
+func createSMNMessageTemplate(c *golangsdk.ServiceClient, name, protocol, content string) (string, error) {
+	b := map[string]string{
+		"message_template_name": name,
+		"protocol":              protocol,
+		"content":               content,
+	}
+	var r struct {
+		ID string `json:"message_template_id"`
+	}
+	_, err := c.Post(smnMessageTemplateURL(c), b, &r, &golangsdk.RequestOpts{
+		OkCodes:     []int{200, 201},
+		MoreHeaders: topics.RequestOpts.MoreHeaders,
+	})
+	return r.ID, err
+}
+
+func getSMNMessageTemplate(c *golangsdk.ServiceClient, id string) (*smnMessageTemplate, error) {
+	var t smnMessageTemplate
+	_, err := c.Get(smnMessageTemplateURL(c, id), &t, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: topics.RequestOpts.MoreHeaders,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func updateSMNMessageTemplate(c *golangsdk.ServiceClient, id, content string) error {
+	_, err := c.Put(smnMessageTemplateURL(c, id), map[string]string{"content": content}, nil, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: topics.RequestOpts.MoreHeaders,
+	})
+	return err
+}
+
+func deleteSMNMessageTemplate(c *golangsdk.ServiceClient, id string) error {
+	_, err := c.Delete(smnMessageTemplateURL(c, id), &golangsdk.RequestOpts{
+		OkCodes:     []int{200, 204},
+		MoreHeaders: topics.RequestOpts.MoreHeaders,
+	})
+	return err
+}
+
+// smnSubscriptionsPageSize is the largest page of subscriptions SMN returns.
+const smnSubscriptionsPageSize = 100
+
+// listSMNTopicSubscriptions returns all the subscriptions of a topic.
+// subscriptions.ListFromTopic only returns the first page of them.
+func listSMNTopicSubscriptions(c *golangsdk.ServiceClient, topicUrn string) ([]subscriptions.SubscriptionGet, error) {
+	var all []subscriptions.SubscriptionGet
+	for offset := 0; ; offset += smnSubscriptionsPageSize {
+		var r struct {
+			Subscriptions []subscriptions.SubscriptionGet `json:"subscriptions"`
+		}
+		url := c.ServiceURL("topics", topicUrn, "subscriptions") +
+			fmt.Sprintf("?offset=%d&limit=%d", offset, smnSubscriptionsPageSize)
+		_, err := c.Get(url, &r, &golangsdk.RequestOpts{
+			OkCodes:     []int{200},
+			MoreHeaders: subscriptions.RequestOpts.MoreHeaders,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, r.Subscriptions...)
+		if len(r.Subscriptions) < smnSubscriptionsPageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package huaweicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+func TestListSMNTopicSubscriptions_paging(t *testing.T) {
+	const total = 230
+	topicUrn := "urn:smn:cn-north-1:project:topic"
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/topics/"+topicUrn+"/subscriptions" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		page := []map[string]interface{}{}
+		for i := offset; i < offset+limit && i < total; i++ {
+			page = append(page, map[string]interface{}{
+				"subscription_urn": fmt.Sprintf("%s:%d", topicUrn, i),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"subscription_count": total,
+			"subscriptions":      page,
+		})
+	}))
+	defer server.Close()
+
+	client := &golangsdk.ServiceClient{
+		ProviderClient: &golangsdk.ProviderClient{},
+		Endpoint:       server.URL + "/",
+	}
+
+	list, err := listSMNTopicSubscriptions(client, topicUrn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != total {
+		t.Fatalf("expected %d subscriptions, got %d", total, len(list))
+	}
+	if list[total-1].SubscriptionUrn != fmt.Sprintf("%s:%d", topicUrn, total-1) {
+		t.Fatalf("unexpected last subscription: %s", list[total-1].SubscriptionUrn)
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 pages to be requested, got %d", requests)
+	}
+}
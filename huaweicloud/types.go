@@ -2,6 +2,7 @@ package huaweicloud
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,9 +10,14 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Unknwon/com"
 
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
@@ -31,8 +37,26 @@ import (
 type LogRoundTripper struct {
 	Rt      http.RoundTripper
 	OsDebug bool
+
+	// SensitiveFields lists the JSON keys whose values are masked in logged
+	// request and response bodies. defaultSensitiveFields is used if empty.
+	SensitiveFields []string
+
+	// HarLog, if set, receives a redacted copy of every request/response pair.
+	HarLog *harLogger
+}
+
+// defaultSensitiveFields are the JSON keys masked in debug output. Keys are
+// matched case-insensitively at any depth of the body.
+var defaultSensitiveFields = []string{
+	"password", "adminPass", "admin_pass", "dbrtpd", "plain_text",
+	"user_data", "secret", "secret_key", "securitytoken", "security_token",
+	"private_key", "psk",
 }
 
+// requestCounter is used to generate the correlation ID of each request.
+var requestCounter uint64
+
 // RoundTrip performs a round-trip HTTP request and logs relevant information about it.
 func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
 	defer func() {
@@ -45,29 +69,45 @@ func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, er
 	//tlsconfig := lrt.Rt.(*http.Transport).TLSClientConfig
 
 	var err error
+	var reqBody, respBody string
+	reqID := fmt.Sprintf("req-%d", atomic.AddUint64(&requestCounter, 1))
+	started := time.Now()
 
 	if lrt.OsDebug {
-		log.Printf("[DEBUG] HuaweiCloud Request URL: %s %s", request.Method, request.URL)
-		log.Printf("[DEBUG] Openstack Request Headers:\n%s", FormatHeaders(request.Header, "\n"))
+		log.Printf("[DEBUG] [%s] HuaweiCloud Request URL: %s %s", reqID, request.Method, request.URL)
+		log.Printf("[DEBUG] [%s] Openstack Request Headers:\n%s", reqID, FormatHeaders(request.Header, "\n"))
+	}
 
-		if request.Body != nil {
-			request.Body, err = lrt.logRequest(request.Body, request.Header.Get("Content-Type"))
-			if err != nil {
-				return nil, err
-			}
+	if request.Body != nil && (lrt.OsDebug || lrt.HarLog != nil) {
+		request.Body, reqBody, err = lrt.logRequest(reqID, request.Body, request.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	response, err := lrt.Rt.RoundTrip(request)
 	if response == nil {
+		if lrt.HarLog != nil {
+			lrt.HarLog.Record(reqID, started, time.Since(started), request, reqBody, nil, "", err)
+		}
 		return nil, err
 	}
 
 	if lrt.OsDebug {
-		log.Printf("[DEBUG] Openstack Response Code: %d", response.StatusCode)
-		log.Printf("[DEBUG] Openstack Response Headers:\n%s", FormatHeaders(response.Header, "\n"))
+		if serverID := responseRequestID(response.Header); serverID != "" {
+			log.Printf("[DEBUG] [%s] Openstack Response Code: %d (server request ID: %s)", reqID, response.StatusCode, serverID)
+		} else {
+			log.Printf("[DEBUG] [%s] Openstack Response Code: %d", reqID, response.StatusCode)
+		}
+		log.Printf("[DEBUG] [%s] Openstack Response Headers:\n%s", reqID, FormatHeaders(response.Header, "\n"))
+	}
+
+	if lrt.OsDebug || lrt.HarLog != nil {
+		response.Body, respBody, err = lrt.logResponse(reqID, response.Body, response.Header.Get("Content-Type"))
+	}
 
-		response.Body, err = lrt.logResponse(response.Body, response.Header.Get("Content-Type"))
+	if lrt.HarLog != nil {
+		lrt.HarLog.Record(reqID, started, time.Since(started), request, reqBody, response, respBody, err)
 	}
 
 	return response, err
@@ -75,51 +115,59 @@ func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, er
 
 // logRequest will log the HTTP Request details.
 // If the body is JSON, it will attempt to be pretty-formatted.
-func (lrt *LogRoundTripper) logRequest(original io.ReadCloser, contentType string) (io.ReadCloser, error) {
+// The redacted body is returned alongside a fresh copy of the original.
+func (lrt *LogRoundTripper) logRequest(reqID string, original io.ReadCloser, contentType string) (io.ReadCloser, string, error) {
 	defer original.Close()
 
 	var bs bytes.Buffer
 	_, err := io.Copy(&bs, original)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// Handle request contentType
+	debugInfo := bs.String()
 	if strings.HasPrefix(contentType, "application/json") {
-		debugInfo := lrt.formatJSON(bs.Bytes())
-		log.Printf("[DEBUG] HuaweiCloud Request Body: %s", debugInfo)
-	} else {
-		log.Printf("[DEBUG] HuaweiCloud Request Body: %s", bs.String())
+		debugInfo = lrt.redactJSON(bs.Bytes())
+	}
+
+	if lrt.OsDebug {
+		log.Printf("[DEBUG] [%s] HuaweiCloud Request Body: %s", reqID, debugInfo)
 	}
 
-	return ioutil.NopCloser(strings.NewReader(bs.String())), nil
+	return ioutil.NopCloser(strings.NewReader(bs.String())), debugInfo, nil
 }
 
 // logResponse will log the HTTP Response details.
 // If the body is JSON, it will attempt to be pretty-formatted.
-func (lrt *LogRoundTripper) logResponse(original io.ReadCloser, contentType string) (io.ReadCloser, error) {
+// The redacted body is returned alongside a fresh copy of the original.
+func (lrt *LogRoundTripper) logResponse(reqID string, original io.ReadCloser, contentType string) (io.ReadCloser, string, error) {
 	if strings.HasPrefix(contentType, "application/json") {
 		var bs bytes.Buffer
 		defer original.Close()
 		_, err := io.Copy(&bs, original)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		debugInfo := lrt.formatJSON(bs.Bytes())
-		if debugInfo != "" {
-			log.Printf("[DEBUG] HuaweiCloud Response Body: %s", debugInfo)
+		if lrt.OsDebug {
+			debugInfo := lrt.formatJSON(bs.Bytes())
+			if debugInfo != "" {
+				log.Printf("[DEBUG] [%s] HuaweiCloud Response Body: %s", reqID, debugInfo)
+			}
 		}
-		return ioutil.NopCloser(strings.NewReader(bs.String())), nil
+		return ioutil.NopCloser(strings.NewReader(bs.String())), lrt.redactJSON(bs.Bytes()), nil
 	}
 
-	log.Printf("[DEBUG] Not logging because HuaweiCloud response body isn't JSON")
-	return original, nil
+	if lrt.OsDebug {
+		log.Printf("[DEBUG] [%s] Not logging because HuaweiCloud response body isn't JSON", reqID)
+	}
+	return original, "", nil
 }
 
 // formatJSON will try to pretty-format a JSON body.
 // It will also mask known fields which contain sensitive information.
 func (lrt *LogRoundTripper) formatJSON(raw []byte) string {
-	var data map[string]interface{}
+	var data interface{}
 
 	err := json.Unmarshal(raw, &data)
 	if err != nil {
@@ -127,25 +175,39 @@ func (lrt *LogRoundTripper) formatJSON(raw []byte) string {
 		return string(raw)
 	}
 
-	// Mask known password fields
-	if v, ok := data["auth"].(map[string]interface{}); ok {
-		if v, ok := v["identity"].(map[string]interface{}); ok {
-			if v, ok := v["password"].(map[string]interface{}); ok {
-				if v, ok := v["user"].(map[string]interface{}); ok {
-					v["password"] = "***"
-				}
+	// Ignore the catalog
+	if m, ok := data.(map[string]interface{}); ok {
+		if v, ok := m["token"].(map[string]interface{}); ok {
+			if _, ok := v["catalog"]; ok {
+				return ""
 			}
 		}
 	}
 
-	// Ignore the catalog
-	if v, ok := data["token"].(map[string]interface{}); ok {
-		if _, ok := v["catalog"]; ok {
-			return ""
-		}
+	return lrt.marshalRedacted(data, raw)
+}
+
+// redactJSON pretty-formats a JSON body with the sensitive fields masked.
+// Unlike formatJSON it never drops the body.
+func (lrt *LogRoundTripper) redactJSON(raw []byte) string {
+	var data interface{}
+
+	err := json.Unmarshal(raw, &data)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to parse HuaweiCloud JSON: %s", err)
+		return string(raw)
+	}
+
+	return lrt.marshalRedacted(data, raw)
+}
+
+func (lrt *LogRoundTripper) marshalRedacted(data interface{}, raw []byte) string {
+	fields := lrt.SensitiveFields
+	if len(fields) == 0 {
+		fields = defaultSensitiveFields
 	}
 
-	pretty, err := json.MarshalIndent(data, "", "  ")
+	pretty, err := json.MarshalIndent(redactSensitiveFields(data, fields), "", "  ")
 	if err != nil {
 		log.Printf("[DEBUG] Unable to re-marshal HuaweiCloud JSON: %s", err)
 		return string(raw)
@@ -154,6 +216,37 @@ func (lrt *LogRoundTripper) formatJSON(raw []byte) string {
 	return string(pretty)
 }
 
+// redactSensitiveFields walks a decoded JSON value and masks the value of
+// every object key listed in fields.
+func redactSensitiveFields(data interface{}, fields []string) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if com.IsSliceContainsStr(fields, key) {
+				v[key] = "***"
+			} else {
+				v[key] = redactSensitiveFields(value, fields)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactSensitiveFields(value, fields)
+		}
+	}
+
+	return data
+}
+
+// responseRequestID returns the ID the server assigned to a request, if any.
+func responseRequestID(headers http.Header) string {
+	for _, name := range []string{"X-Openstack-Request-Id", "X-Compute-Request-Id", "X-Request-Id"} {
+		if v := headers.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // FirewallGroup is an HuaweiCloud firewall group.
 type FirewallGroup struct {
 	firewall_groups.FirewallGroup
@@ -205,6 +298,38 @@ func (opts KeyPairCreateOpts) ToKeyPairCreateMap() (map[string]interface{}, erro
 	return BuildRequest(opts, "keypair")
 }
 
+// ServerRebuildOpts represents the attributes used when rebuilding a server.
+// A nil UserData leaves the user data of the server as is, an empty one
+// removes it.
+type ServerRebuildOpts struct {
+	servers.RebuildOpts
+	UserData *string
+}
+
+// ToServerRebuildMap casts a ServerRebuildOpts struct to a map.
+// It overrides servers.ToServerRebuildMap to add the UserData field.
+func (opts ServerRebuildOpts) ToServerRebuildMap() (map[string]interface{}, error) {
+	b, err := opts.RebuildOpts.ToServerRebuildMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.UserData != nil {
+		var userData interface{}
+		if *opts.UserData != "" {
+			// User data is sent base64 encoded, as on server creation.
+			v := *opts.UserData
+			if _, err := base64.StdEncoding.DecodeString(v); err != nil {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			userData = v
+		}
+		b["rebuild"].(map[string]interface{})["user_data"] = userData
+	}
+
+	return b, nil
+}
+
 // NetworkCreateOpts represents the attributes used when creating a new network.
 type NetworkCreateOpts struct {
 	networks.CreateOpts
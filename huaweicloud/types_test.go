@@ -0,0 +1,112 @@
+package huaweicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRedactSensitiveFields(t *testing.T) {
+	raw := `{
+		"server": {
+			"name": "test",
+			"adminPass": "secret1",
+			"user_data": "IyEvYmluL3No",
+			"metadata": [{"plain_text": "secret2"}, {"key": "value"}]
+		},
+		"instance": {"dbRtPd": "secret3"}
+	}`
+
+	lrt := &LogRoundTripper{}
+	redacted := lrt.redactJSON([]byte(raw))
+
+	for _, secret := range []string{"secret1", "secret2", "secret3", "IyEvYmluL3No"} {
+		if strings.Contains(redacted, secret) {
+			t.Fatalf("%q was not redacted: %s", secret, redacted)
+		}
+	}
+	for _, kept := range []string{`"name": "test"`, `"key": "value"`} {
+		if !strings.Contains(redacted, kept) {
+			t.Fatalf("%q was unexpectedly redacted: %s", kept, redacted)
+		}
+	}
+
+	lrt.SensitiveFields = []string{"name"}
+	if redacted := lrt.redactJSON([]byte(raw)); strings.Contains(redacted, `"test"`) {
+		t.Fatalf("custom sensitive field was not redacted: %s", redacted)
+	}
+}
+
+func TestLogRoundTripper_har(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Openstack-Request-Id", "server-req-1")
+		fmt.Fprint(w, `{"key": {"plain_text": "response-secret"}}`)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	har, err := getHarLogger(filepath.Join(dir, "test.har"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := http.Client{
+		Transport: &LogRoundTripper{Rt: http.DefaultTransport, HarLog: har},
+	}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("POST", server.URL+"/v2/servers?limit=1",
+			strings.NewReader(`{"server": {"admin_pass": "request-secret"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Auth-Token", "token-secret")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), "response-secret") {
+			t.Fatalf("response body was altered: %s", body)
+		}
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "test.har"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"request-secret", "response-secret", "token-secret"} {
+		if strings.Contains(string(contents), secret) {
+			t.Fatalf("%q was not redacted in HAR file: %s", secret, contents)
+		}
+	}
+
+	var doc struct {
+		Log struct {
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(contents, &doc); err != nil {
+		t.Fatalf("HAR file is not valid JSON: %s\n%s", err, contents)
+	}
+	if len(doc.Log.Entries) != 2 {
+		t.Fatalf("expected 2 HAR entries, got %d", len(doc.Log.Entries))
+	}
+	entry := doc.Log.Entries[1]
+	if entry.Response.Status != 200 || entry.ServerRequestID != "server-req-1" {
+		t.Fatalf("unexpected HAR entry: %#v", entry)
+	}
+	if entry.RequestID == doc.Log.Entries[0].RequestID {
+		t.Fatalf("expected distinct request IDs, got %s twice", entry.RequestID)
+	}
+}
@@ -86,7 +86,7 @@ func MapResourceProp(d *schema.ResourceData, prop string) map[string]interface{}
 var REDACT_HEADERS = []string{"x-auth-token", "x-auth-key", "x-service-token",
 	"x-storage-token", "x-account-meta-temp-url-key", "x-account-meta-temp-url-key-2",
 	"x-container-meta-temp-url-key", "x-container-meta-temp-url-key-2", "set-cookie",
-	"x-subject-token"}
+	"x-subject-token", "authorization", "x-security-token", "x-amz-security-token"}
 
 // RedactHeaders processes a headers object, returning a redacted list
 func RedactHeaders(headers http.Header) (processedHeaders []string) {
@@ -166,3 +166,12 @@ func resourceNetworkingAvailabilityZoneHintsV2(d *schema.ResourceData) []string
 	}
 	return azh
 }
+
+// expandStringSet returns the strings of a set of strings.
+func expandStringSet(s *schema.Set) []string {
+	list := make([]string, 0, s.Len())
+	for _, v := range s.List() {
+		list = append(list, v.(string))
+	}
+	return list
+}
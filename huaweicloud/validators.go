@@ -53,6 +53,13 @@ func validateTrueOnly(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+// validateUnset rejects any configured value, for the arguments which are
+// only Optional so that the plan shows the changes towards an empty value.
+func validateUnset(v interface{}, k string) (ws []string, errors []error) {
+	errors = append(errors, fmt.Errorf("%q is set on refresh and cannot be configured", k))
+	return
+}
+
 func validateS3BucketLifecycleExpirationDays(v interface{}, k string) (ws []string, errors []error) {
 	if v.(int) <= 0 {
 		errors = append(errors, fmt.Errorf(
@@ -62,6 +69,36 @@ func validateS3BucketLifecycleExpirationDays(v interface{}, k string) (ws []stri
 	return
 }
 
+func validateS3BucketLifecycleStorageClass(v interface{}, k string) (ws []string, errors []error) {
+	return ValidateStringList(v, k, []string{"WARM", "COLD"})
+}
+
+func validateS3BucketReplicationRuleId(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 255 {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be longer than 255 characters", k))
+	}
+	return
+}
+
+func validateS3BucketReplicationRulePrefix(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 1024 {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be longer than 1024 characters", k))
+	}
+	return
+}
+
+func validateS3BucketReplicationRuleStatus(v interface{}, k string) (ws []string, errors []error) {
+	return ValidateStringList(v, k, []string{"Enabled", "Disabled"})
+}
+
+func validateS3BucketReplicationStorageClass(v interface{}, k string) (ws []string, errors []error) {
+	return ValidateStringList(v, k, []string{"STANDARD", "WARM", "COLD"})
+}
+
 func validateS3BucketLifecycleRuleId(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if len(value) > 255 {
@@ -78,6 +115,13 @@ func validateJsonString(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+func validateRegexp(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid regular expression: %s", k, err))
+	}
+	return
+}
+
 func validateKmsKeyStatus(v interface{}, k string) (ws []string, errors []error) {
 	status := v.(string)
 	if status != EnabledState && status != DisabledState && status != PendingDeletionState {
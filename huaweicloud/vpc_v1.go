@@ -0,0 +1,188 @@
+package huaweicloud
+
+import (
+	"fmt"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/eips"
+)
+
+// vpcFlowLog is a flow log of a VPC, a subnet (network) or a port.
+type vpcFlowLog struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	TrafficType  string `json:"traffic_type,omitempty"`
+	LogGroupID   string `json:"log_group_id,omitempty"`
+	LogTopicID   string `json:"log_topic_id,omitempty"`
+	AdminStateUp *bool  `json:"admin_state_up,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
+
+// vpcFlowLogUpdate updates the name, the description and the state of a
+// flow log, the other fields cannot be changed.
+type vpcFlowLogUpdate struct {
+	Name         string  `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+}
+
+func vpcFlowLogURL(c *golangsdk.ServiceClient, id ...string) string {
+	return c.ServiceURL(append([]string{c.ProjectID, "fl", "flow_logs"}, id...)...)
+}
+
+func createVpcFlowLog(c *golangsdk.ServiceClient, f vpcFlowLog) (*vpcFlowLog, error) {
+	var r struct {
+		FlowLog *vpcFlowLog `json:"flow_log"`
+	}
+	_, err := c.Post(vpcFlowLogURL(c), map[string]interface{}{"flow_log": f}, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	return r.FlowLog, err
+}
+
+func getVpcFlowLog(c *golangsdk.ServiceClient, id string) (*vpcFlowLog, error) {
+	var r struct {
+		FlowLog *vpcFlowLog `json:"flow_log"`
+	}
+	_, err := c.Get(vpcFlowLogURL(c, id), &r, nil)
+	return r.FlowLog, err
+}
+
+func updateVpcFlowLog(c *golangsdk.ServiceClient, id string, u vpcFlowLogUpdate) (*vpcFlowLog, error) {
+	var r struct {
+		FlowLog *vpcFlowLog `json:"flow_log"`
+	}
+	_, err := c.Put(vpcFlowLogURL(c, id), map[string]interface{}{"flow_log": u}, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return r.FlowLog, err
+}
+
+func deleteVpcFlowLog(c *golangsdk.ServiceClient, id string) error {
+	_, err := c.Delete(vpcFlowLogURL(c, id), nil)
+	return err
+}
+
+// vpcRoute is a route of a route table, Type is the type of the next hop:
+// ecs, eni, vip, nat, peering, vpn, dc or cc.
+type vpcRoute struct {
+	Type        string `json:"type,omitempty"`
+	Destination string `json:"destination"`
+	NextHop     string `json:"nexthop,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type vpcRouteTableSubnet struct {
+	ID string `json:"id"`
+}
+
+// vpcRouteTable is a custom route table of a VPC.
+type vpcRouteTable struct {
+	ID          string                `json:"id,omitempty"`
+	Name        string                `json:"name,omitempty"`
+	Description string                `json:"description,omitempty"`
+	VpcID       string                `json:"vpc_id,omitempty"`
+	Default     bool                  `json:"default,omitempty"`
+	Routes      []vpcRoute            `json:"routes,omitempty"`
+	Subnets     []vpcRouteTableSubnet `json:"subnets,omitempty"`
+}
+
+// vpcRouteTableUpdate renames a route table and adds, modifies or deletes
+// some of its routes, the routes are identified by their destination.
+type vpcRouteTableUpdate struct {
+	Name        string                `json:"name,omitempty"`
+	Description *string               `json:"description,omitempty"`
+	Routes      map[string][]vpcRoute `json:"routes,omitempty"`
+}
+
+func vpcRouteTableURL(c *golangsdk.ServiceClient, id ...string) string {
+	return c.ServiceURL(append([]string{c.ProjectID, "routetables"}, id...)...)
+}
+
+func createVpcRouteTable(c *golangsdk.ServiceClient, t vpcRouteTable) (*vpcRouteTable, error) {
+	var r struct {
+		RouteTable *vpcRouteTable `json:"routetable"`
+	}
+	_, err := c.Post(vpcRouteTableURL(c), map[string]interface{}{"routetable": t}, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	return r.RouteTable, err
+}
+
+func getVpcRouteTable(c *golangsdk.ServiceClient, id string) (*vpcRouteTable, error) {
+	var r struct {
+		RouteTable *vpcRouteTable `json:"routetable"`
+	}
+	_, err := c.Get(vpcRouteTableURL(c, id), &r, nil)
+	return r.RouteTable, err
+}
+
+func updateVpcRouteTable(c *golangsdk.ServiceClient, id string, u vpcRouteTableUpdate) (*vpcRouteTable, error) {
+	var r struct {
+		RouteTable *vpcRouteTable `json:"routetable"`
+	}
+	_, err := c.Put(vpcRouteTableURL(c, id), map[string]interface{}{"routetable": u}, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return r.RouteTable, err
+}
+
+// associateVpcRouteTableSubnets associates subnets with the route table and
+// disassociates others, which then use the default route table of the VPC.
+func associateVpcRouteTableSubnets(c *golangsdk.ServiceClient, id string, associate, disassociate []string) error {
+	subnets := make(map[string][]string)
+	if len(associate) > 0 {
+		subnets["associate"] = associate
+	}
+	if len(disassociate) > 0 {
+		subnets["disassociate"] = disassociate
+	}
+	if len(subnets) == 0 {
+		return nil
+	}
+
+	b := map[string]interface{}{
+		"routetable": map[string]interface{}{"subnets": subnets},
+	}
+	_, err := c.Post(vpcRouteTableURL(c, id, "action"), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
+
+func deleteVpcRouteTable(c *golangsdk.ServiceClient, id string) error {
+	_, err := c.Delete(vpcRouteTableURL(c, id), nil)
+	return err
+}
+
+// vpcEIPPageSize is the number of EIPs listed per request.
+const vpcEIPPageSize = 100
+
+// listVpcEIPs returns all the EIPs of the project, the API pages them by
+// marker.
+func listVpcEIPs(c *golangsdk.ServiceClient) ([]eips.PublicIp, error) {
+	var all []eips.PublicIp
+	marker := ""
+	for {
+		url := c.ServiceURL(c.ProjectID, "publicips") + fmt.Sprintf("?limit=%d", vpcEIPPageSize)
+		if marker != "" {
+			url += "&marker=" + marker
+		}
+
+		var r struct {
+			PublicIPs []eips.PublicIp `json:"publicips"`
+		}
+		if _, err := c.Get(url, &r, nil); err != nil {
+			return nil, err
+		}
+		all = append(all, r.PublicIPs...)
+
+		if len(r.PublicIPs) < vpcEIPPageSize {
+			return all, nil
+		}
+		marker = r.PublicIPs[len(r.PublicIPs)-1].ID
+	}
+}
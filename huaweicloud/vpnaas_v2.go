@@ -0,0 +1,250 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+type vpnService struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	AdminStateUp *bool  `json:"admin_state_up,omitempty"`
+	RouterID     string `json:"router_id,omitempty"`
+	SubnetID     string `json:"subnet_id,omitempty"`
+	TenantID     string `json:"tenant_id,omitempty"`
+	Status       string `json:"status,omitempty"`
+	ExternalV4IP string `json:"external_v4_ip,omitempty"`
+	ExternalV6IP string `json:"external_v6_ip,omitempty"`
+}
+
+// vpnLifetime is the lifetime of the security associations of a policy.
+type vpnLifetime struct {
+	Units string `json:"units,omitempty"`
+	Value int    `json:"value,omitempty"`
+}
+
+type vpnIKEPolicy struct {
+	ID                    string       `json:"id,omitempty"`
+	Name                  string       `json:"name,omitempty"`
+	Description           string       `json:"description,omitempty"`
+	AuthAlgorithm         string       `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm   string       `json:"encryption_algorithm,omitempty"`
+	PFS                   string       `json:"pfs,omitempty"`
+	IKEVersion            string       `json:"ike_version,omitempty"`
+	Phase1NegotiationMode string       `json:"phase1_negotiation_mode,omitempty"`
+	Lifetime              *vpnLifetime `json:"lifetime,omitempty"`
+	TenantID              string       `json:"tenant_id,omitempty"`
+}
+
+type vpnIPSecPolicy struct {
+	ID                  string       `json:"id,omitempty"`
+	Name                string       `json:"name,omitempty"`
+	Description         string       `json:"description,omitempty"`
+	AuthAlgorithm       string       `json:"auth_algorithm,omitempty"`
+	EncapsulationMode   string       `json:"encapsulation_mode,omitempty"`
+	EncryptionAlgorithm string       `json:"encryption_algorithm,omitempty"`
+	PFS                 string       `json:"pfs,omitempty"`
+	TransformProtocol   string       `json:"transform_protocol,omitempty"`
+	Lifetime            *vpnLifetime `json:"lifetime,omitempty"`
+	TenantID            string       `json:"tenant_id,omitempty"`
+}
+
+type vpnEndpointGroup struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Endpoints   []string `json:"endpoints,omitempty"`
+	TenantID    string   `json:"tenant_id,omitempty"`
+}
+
+// vpnDPD configures the dead peer detection of a site connection.
+type vpnDPD struct {
+	Action   string `json:"action,omitempty"`
+	Interval int    `json:"interval,omitempty"`
+	Timeout  int    `json:"timeout,omitempty"`
+}
+
+type vpnSiteConnection struct {
+	ID             string   `json:"id,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	VPNServiceID   string   `json:"vpnservice_id,omitempty"`
+	IKEPolicyID    string   `json:"ikepolicy_id,omitempty"`
+	IPSecPolicyID  string   `json:"ipsecpolicy_id,omitempty"`
+	PeerAddress    string   `json:"peer_address,omitempty"`
+	PeerID         string   `json:"peer_id,omitempty"`
+	LocalID        string   `json:"local_id,omitempty"`
+	LocalEPGroupID string   `json:"local_ep_group_id,omitempty"`
+	PeerEPGroupID  string   `json:"peer_ep_group_id,omitempty"`
+	PeerCIDRs      []string `json:"peer_cidrs,omitempty"`
+	PSK            string   `json:"psk,omitempty"`
+	MTU            int      `json:"mtu,omitempty"`
+	Initiator      string   `json:"initiator,omitempty"`
+	DPD            *vpnDPD  `json:"dpd,omitempty"`
+	AdminStateUp   *bool    `json:"admin_state_up,omitempty"`
+	TenantID       string   `json:"tenant_id,omitempty"`
+	Status         string   `json:"status,omitempty"`
+}
+
+// vpnSiteConnectionUpdate sends peer_cidrs even when it is emptied, which
+// vpnSiteConnection leaves out.
+type vpnSiteConnectionUpdate struct {
+	vpnSiteConnection
+	PeerCIDRs *[]string `json:"peer_cidrs,omitempty"`
+}
+
+// vpnResource is a kind of VPNaaS resource: its collection path and the key
+// wrapping it in requests and responses.
+type vpnResource struct {
+	path string
+	key  string
+}
+
+var (
+	vpnServices        = vpnResource{"vpnservices", "vpnservice"}
+	vpnIKEPolicies     = vpnResource{"ikepolicies", "ikepolicy"}
+	vpnIPSecPolicies   = vpnResource{"ipsecpolicies", "ipsecpolicy"}
+	vpnEndpointGroups  = vpnResource{"endpoint-groups", "endpoint_group"}
+	vpnSiteConnections = vpnResource{"ipsec-site-connections", "ipsec_site_connection"}
+)
+
+func (r vpnResource) url(c *golangsdk.ServiceClient, id ...string) string {
+	return c.ServiceURL(append([]string{"vpn", r.path}, id...)...)
+}
+
+func (r vpnResource) create(c *golangsdk.ServiceClient, opts, to interface{}) error {
+	var res golangsdk.Result
+	_, res.Err = c.Post(r.url(c), map[string]interface{}{r.key: opts}, &res.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if res.Err != nil {
+		return res.Err
+	}
+	return res.ExtractIntoStructPtr(to, r.key)
+}
+
+func (r vpnResource) get(c *golangsdk.ServiceClient, id string, to interface{}) error {
+	var res golangsdk.Result
+	_, res.Err = c.Get(r.url(c, id), &res.Body, nil)
+	if res.Err != nil {
+		return res.Err
+	}
+	return res.ExtractIntoStructPtr(to, r.key)
+}
+
+func (r vpnResource) update(c *golangsdk.ServiceClient, id string, opts interface{}) error {
+	_, err := c.Put(r.url(c, id), map[string]interface{}{r.key: opts}, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
+
+func (r vpnResource) delete(c *golangsdk.ServiceClient, id string) error {
+	_, err := c.Delete(r.url(c, id), nil)
+	return err
+}
+
+// waitForVpnStatus waits for a VPN service or a site connection to leave its
+// PENDING_* status, they are DOWN until a connection is established.
+func (r vpnResource) waitForVpnStatus(c *golangsdk.ServiceClient, id string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"PENDING_CREATE", "PENDING_UPDATE"},
+		Target:     []string{"ACTIVE", "DOWN"},
+		Refresh:    r.statusRefreshFunc(c, id),
+		Timeout:    timeout,
+		Delay:      0,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// waitForDeletion waits until the resource is not found anymore.
+func (r vpnResource) waitForDeletion(c *golangsdk.ServiceClient, id string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE", "DOWN", "ERROR", "PENDING_DELETE"},
+		Target:     []string{"DELETED"},
+		Refresh:    r.statusRefreshFunc(c, id),
+		Timeout:    timeout,
+		Delay:      0,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func (r vpnResource) statusRefreshFunc(c *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var v struct {
+			Status string `json:"status"`
+		}
+		err := r.get(c, id, &v)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[DEBUG] VPN %s %s is deleted", r.key, id)
+				return v, "DELETED", nil
+			}
+			return nil, "", fmt.Errorf("Error retrieving VPN %s %s: %s", r.key, id, err)
+		}
+
+		log.Printf("[DEBUG] VPN %s %s status: %s", r.key, id, v.Status)
+		return v, v.Status, nil
+	}
+}
+
+// vpnLifetimeSchema is the schema of the lifetime of a policy.
+func vpnLifetimeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"units": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"value": &schema.Schema{
+					Type:     schema.TypeInt,
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func expandVpnLifetime(d *schema.ResourceData) *vpnLifetime {
+	list := d.Get("lifetime").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	return &vpnLifetime{
+		Units: m["units"].(string),
+		Value: m["value"].(int),
+	}
+}
+
+func flattenVpnLifetime(l *vpnLifetime) []map[string]interface{} {
+	if l == nil {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"units": l.Units,
+			"value": l.Value,
+		},
+	}
+}